@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"claude-session-manager/internal/doctor"
+)
+
+// runDoctor implements `ccdeck doctor [--json]` and returns the exit code:
+// 1 when any check failed, 2 on bad usage.
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	report := doctor.Run()
+	if *asJSON {
+		if err := report.WriteJSON(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	} else {
+		report.WriteText(os.Stdout)
+	}
+	if !report.OK {
+		return 1
+	}
+	return 0
+}
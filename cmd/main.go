@@ -25,6 +25,10 @@ func main() {
 		case "--version", "-v":
 			fmt.Printf("ccdeck %s (%s)\n", Version, Commit)
 			return
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:]))
+		case "usage":
+			os.Exit(runUsage(os.Args[2:]))
 		}
 	}
 
@@ -33,16 +37,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	cfg, err := model.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := tui.ApplyTheme(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	store, err := model.NewStore()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	app := tui.New(store)
-	p := tea.NewProgram(app, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	app := tui.New(store, cfg)
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if m, ok := final.(tui.Model); ok && m.QuitErr() != nil {
+		fmt.Fprintf(os.Stderr, "Error saving state: %v\n", m.QuitErr())
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/usage"
+)
+
+// runUsage implements `ccdeck usage [--since WHEN]` and returns the exit
+// code: 1 when the data could not be read, 2 on bad usage.
+func runUsage(args []string) int {
+	fs := flag.NewFlagSet("usage", flag.ContinueOnError)
+	sinceFlag := fs.String("since", "", "only count usage after this: a duration (24h, 7d, 2w) or a date (2006-01-02)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	since, err := parseSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	cfg, err := model.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	path, err := model.DataPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	store, err := model.OpenStore(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if err := writeUsageReport(os.Stdout, store, cfg, since); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseSince accepts "" (all time), a Go duration, a number of days or
+// weeks ("7d", "2w"), or a date in YYYY-MM-DD form (local time).
+func parseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if unit := s[len(s)-1]; unit == 'd' || unit == 'w' {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 {
+			days := n
+			if unit == 'w' {
+				days *= 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration like 24h, 7d or 2w, or a date like 2006-01-02", s)
+}
+
+// writeUsageReport prints a table of usage per session with group subtotals
+// and a grand total. Transcripts that cannot be read are reported below the
+// table instead of failing the whole report.
+func writeUsageReport(w io.Writer, store *model.Store, cfg *model.Config, since time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row := func(group, session string, u usage.Usage) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", group, session,
+			usage.FormatTokens(u.Input), usage.FormatTokens(u.Output),
+			usage.FormatTokens(u.CacheWrite), usage.FormatTokens(u.CacheRead), u.FormatCost())
+	}
+	fmt.Fprintln(tw, "GROUP\tSESSION\tINPUT\tOUTPUT\tCACHE WRITE\tCACHE READ\tCOST")
+
+	var total usage.Usage
+	var problems []string
+	for gi, g := range store.Groups() {
+		path := store.GroupPath(gi)
+		var sub usage.Usage
+		for _, s := range g.Sessions {
+			u, err := usage.ForSession(s, since, cfg)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s/%s: %v", path, s.Name, err))
+				continue
+			}
+			row(path, s.Name, u)
+			sub.Add(u)
+		}
+		if len(g.Sessions) > 1 {
+			row("", "subtotal", sub)
+		}
+		total.Add(sub)
+	}
+	row("TOTAL", "", total)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if !since.IsZero() {
+		fmt.Fprintf(w, "\nSince %s.\n", since.Format("2006-01-02 15:04"))
+	}
+	if total.Unpriced {
+		fmt.Fprintln(w, "Costs marked + include tokens from models without a price; add them under \"prices\" in config.json.")
+	}
+	for _, p := range problems {
+		fmt.Fprintf(w, "skipped %s\n", p)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "", want: time.Time{}},
+		{in: "  ", want: time.Time{}},
+		{in: "7d", want: now.AddDate(0, 0, -7)},
+		{in: "0d", want: now},
+		{in: "2w", want: now.AddDate(0, 0, -14)},
+		{in: "24h", want: now.Add(-24 * time.Hour)},
+		{in: "90m", want: now.Add(-90 * time.Minute)},
+		{in: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{in: "-1d", wantErr: true},
+		{in: "-1h", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "1.5w", wantErr: true},
+		{in: "2026-13-01", wantErr: true},
+		{in: "last week", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseSince(tt.in, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSince(%q) = %v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
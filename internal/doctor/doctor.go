@@ -0,0 +1,311 @@
+// Package doctor diagnoses the environment ccdeck depends on: tmux, the
+// claude binary, the data file and the stored sessions.
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+)
+
+// minTmuxMajor and minTmuxMinor are the oldest tmux with control-mode
+// attach flags (LIVE mode) and new-session -e (claude env).
+const (
+	minTmuxMajor = 3
+	minTmuxMinor = 2
+)
+
+var tmuxVersionRe = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Check is one diagnostic with an actionable fix when it did not pass.
+type Check struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Report is the result of Run.
+type Report struct {
+	OK     bool    `json:"ok"`
+	Checks []Check `json:"checks"`
+}
+
+// Run performs every check. Later checks that need tmux or the data file are
+// skipped when those are unavailable.
+func Run() Report {
+	var r Report
+	add := func(c Check) { r.Checks = append(r.Checks, c) }
+
+	tmuxOK := tmux.IsInstalled()
+	add(checkTmux(tmuxOK))
+	serverOK := false
+	if tmuxOK {
+		var c Check
+		c, serverOK = checkServer()
+		add(c)
+	}
+	add(checkClaude())
+	add(checkConfig())
+
+	store, c := checkData()
+	add(c)
+	if store != nil {
+		add(checkPaths(store))
+		add(checkCollisions(store))
+		if serverOK {
+			add(checkOrphans(store))
+		}
+	}
+
+	r.OK = true
+	for _, c := range r.Checks {
+		if c.Status == StatusFail {
+			r.OK = false
+		}
+	}
+	return r
+}
+
+func checkTmux(installed bool) Check {
+	c := Check{Name: "tmux"}
+	if !installed {
+		c.Status = StatusFail
+		c.Detail = "tmux not found on PATH"
+		c.Fix = "install tmux (brew install tmux / apt install tmux) and make sure it is on PATH"
+		return c
+	}
+	v, err := tmux.Version()
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = "check that the tmux on PATH runs: tmux -V"
+		return c
+	}
+	c.Status = StatusOK
+	c.Detail = v
+	if m := tmuxVersionRe.FindStringSubmatch(v); m != nil {
+		major, _ := strconv.Atoi(m[1])
+		minor, _ := strconv.Atoi(m[2])
+		if major < minTmuxMajor || (major == minTmuxMajor && minor < minTmuxMinor) {
+			c.Status = StatusWarn
+			c.Detail = fmt.Sprintf("%s is older than %d.%d: LIVE mode falls back to one tmux process per batch and claude env is not set",
+				v, minTmuxMajor, minTmuxMinor)
+			c.Fix = fmt.Sprintf("upgrade tmux to %d.%d or newer", minTmuxMajor, minTmuxMinor)
+		}
+	}
+	return c
+}
+
+// checkServer reports whether the tmux server is reachable; running is
+// false when there is none, so checks that list sessions are skipped.
+func checkServer() (c Check, running bool) {
+	c = Check{Name: "tmux server"}
+	running, err := tmux.ServerRunning()
+	switch {
+	case err != nil:
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = "check TMUX_TMPDIR and socket permissions, or restart the server with tmux kill-server"
+	case !running:
+		c.Status = StatusOK
+		c.Detail = "no server running; one starts with the first session"
+	default:
+		c.Status = StatusOK
+		c.Detail = "reachable"
+	}
+	return c, running
+}
+
+func checkClaude() Check {
+	c := Check{Name: "claude"}
+	path, err := exec.LookPath("claude")
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = "claude not found on PATH"
+		c.Fix = "install the Claude CLI (npm install -g @anthropic-ai/claude-code) and make sure it is on PATH"
+		return c
+	}
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		c.Status = StatusWarn
+		c.Detail = fmt.Sprintf("%s (claude --version failed: %v)", path, err)
+		c.Fix = "run claude --version by hand to see what is wrong"
+		return c
+	}
+	c.Status = StatusOK
+	c.Detail = fmt.Sprintf("%s (%s)", strings.TrimSpace(string(out)), path)
+	return c
+}
+
+func checkConfig() Check {
+	c := Check{Name: "config.json"}
+	if _, err := model.LoadConfig(); err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = "fix the JSON in ~/.config/claude-session-manager/config.json or remove the file"
+		return c
+	}
+	c.Status = StatusOK
+	c.Detail = "valid or absent"
+	return c
+}
+
+func checkData() (*model.Store, Check) {
+	c := Check{Name: "data.json"}
+	path, err := model.DataPath()
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = "make sure ~/.config is writable"
+		return nil, c
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		c.Status = StatusOK
+		c.Detail = path + " not created yet"
+		return &model.Store{}, c
+	}
+	store, err := model.OpenStore(path)
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = fmt.Sprintf("%s: %v", path, err)
+		c.Fix = "fix the JSON by hand, or move the file aside to start with an empty tree"
+		return nil, c
+	}
+	n := 0
+	for _, g := range store.Groups() {
+		n += len(g.Sessions)
+	}
+	c.Status = StatusOK
+	c.Detail = fmt.Sprintf("%s (%d groups, %d sessions)", path, len(store.Groups()), n)
+	return store, c
+}
+
+func checkPaths(store *model.Store) Check {
+	c := Check{Name: "session paths"}
+	var missing []string
+	for gi, g := range store.Groups() {
+		for _, s := range g.Sessions {
+			if info, err := os.Stat(model.ExpandPath(s.Path)); err != nil || !info.IsDir() {
+				missing = append(missing, fmt.Sprintf("%s/%s: %s", store.GroupPath(gi), s.Name, s.Path))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		c.Status = StatusFail
+		c.Detail = "missing directories: " + strings.Join(missing, "; ")
+		c.Fix = "recreate the directories, or delete and re-add these sessions with the right path"
+		return c
+	}
+	c.Status = StatusOK
+	c.Detail = "all stored paths exist"
+	return c
+}
+
+func checkCollisions(store *model.Store) Check {
+	c := Check{Name: "tmux names"}
+	byName := make(map[string][]string)
+	for gi, g := range store.Groups() {
+		path := store.GroupPath(gi)
+		for _, s := range g.Sessions {
+			tn := tmux.SanitizeName(path, s.Name)
+			byName[tn] = append(byName[tn], path+"/"+s.Name)
+		}
+	}
+	var clashes []string
+	for tn, owners := range byName {
+		if len(owners) > 1 {
+			clashes = append(clashes, fmt.Sprintf("%s ← %s", tn, strings.Join(owners, ", ")))
+		}
+	}
+	sort.Strings(clashes)
+	if len(clashes) > 0 {
+		c.Status = StatusFail
+		c.Detail = "sessions share a tmux session: " + strings.Join(clashes, "; ")
+		c.Fix = "rename sessions (r in the tree) until each maps to its own tmux name; only letters, digits, - and _ count"
+		return c
+	}
+	c.Status = StatusOK
+	c.Detail = "no collisions"
+	return c
+}
+
+func checkOrphans(store *model.Store) Check {
+	c := Check{Name: "orphaned sessions"}
+	running, err := tmux.ListSessions()
+	if err != nil {
+		c.Status = StatusWarn
+		c.Detail = err.Error()
+		c.Fix = "run tmux list-sessions by hand to see what is wrong"
+		return c
+	}
+	known := make(map[string]bool)
+	for gi, g := range store.Groups() {
+		for _, s := range g.Sessions {
+			known[tmux.SanitizeName(store.GroupPath(gi), s.Name)] = true
+		}
+	}
+	var orphans []string
+	for _, tn := range running {
+		if strings.HasPrefix(tn, tmux.SessionPrefix) && !known[tn] {
+			orphans = append(orphans, tn)
+		}
+	}
+	if len(orphans) > 0 {
+		c.Status = StatusWarn
+		c.Detail = strings.Join(orphans, ", ")
+		c.Fix = "open ccdeck and press O to attach, adopt or kill them"
+		return c
+	}
+	c.Status = StatusOK
+	c.Detail = "none"
+	return c
+}
+
+// WriteText prints the report for a terminal.
+func (r Report) WriteText(w io.Writer) {
+	marks := map[Status]string{StatusOK: "✓", StatusWarn: "!", StatusFail: "✗"}
+	for _, c := range r.Checks {
+		fmt.Fprintf(w, "%s %-18s %s\n", marks[c.Status], c.Name, c.Detail)
+		if c.Fix != "" {
+			fmt.Fprintf(w, "  %-18s → %s\n", "", c.Fix)
+		}
+	}
+	warned := false
+	for _, c := range r.Checks {
+		warned = warned || c.Status == StatusWarn
+	}
+	switch {
+	case !r.OK:
+		fmt.Fprintln(w, "\nSome checks failed; see the fixes above.")
+	case warned:
+		fmt.Fprintln(w, "\nNo failures, but see the warnings above.")
+	default:
+		fmt.Fprintln(w, "\nNo problems found.")
+	}
+}
+
+// WriteJSON prints the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
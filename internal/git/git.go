@@ -0,0 +1,173 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// run executes git in dir and returns trimmed stdout.
+func run(dir string, args ...string) (string, error) {
+	out, err := output(dir, args...)
+	return strings.TrimSpace(out), err
+}
+
+// output executes git in dir and returns stdout as is.
+func output(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// CurrentBranch returns the checked-out branch in dir, or the short commit
+// hash when HEAD is detached.
+func CurrentBranch(dir string) (string, error) {
+	if branch, err := run(dir, "symbolic-ref", "--short", "-q", "HEAD"); err == nil && branch != "" {
+		return branch, nil
+	}
+	return run(dir, "rev-parse", "--short", "HEAD")
+}
+
+// RepoRoot returns the top-level directory of the work tree containing dir.
+func RepoRoot(dir string) (string, error) {
+	return run(dir, "rev-parse", "--show-toplevel")
+}
+
+// Status summarizes a work tree.
+type Status struct {
+	Branch   string // branch name, or short commit hash when detached
+	Detached bool
+	Upstream string // "" when the branch has no upstream
+	Ahead    int
+	Behind   int
+	Dirty    int // changed, staged, unmerged and untracked paths
+}
+
+// GetStatus reads the branch, upstream divergence and dirty-file count of
+// the work tree containing dir with a single git status call.
+func GetStatus(dir string) (Status, error) {
+	out, err := run(dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return Status{}, err
+	}
+	var st Status
+	var oid string
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			oid = strings.TrimPrefix(line, "# branch.oid ")
+		case strings.HasPrefix(line, "# branch.head "):
+			st.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.upstream "):
+			st.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &st.Ahead, &st.Behind)
+		case line == "" || strings.HasPrefix(line, "#"):
+		default:
+			st.Dirty++
+		}
+	}
+	if st.Branch == "(detached)" {
+		st.Detached = true
+		st.Branch = oid
+		if len(st.Branch) > 7 {
+			st.Branch = st.Branch[:7]
+		}
+	}
+	return st, nil
+}
+
+// AddWorktree creates a work tree at dir on a new branch forked from the
+// current HEAD of repo.
+func AddWorktree(repo, dir, branch string) error {
+	_, err := run(repo, "worktree", "add", "-b", branch, dir)
+	return err
+}
+
+// RemoveWorktree removes the work tree at dir. git refuses when it has
+// uncommitted changes. The branch is kept.
+func RemoveWorktree(repo, dir string) error {
+	_, err := run(repo, "worktree", "remove", dir)
+	return err
+}
+
+// HeadCommit returns the full hash of HEAD in dir.
+func HeadCommit(dir string) (string, error) {
+	return run(dir, "rev-parse", "HEAD")
+}
+
+// Diff returns the unified diff of the work tree in dir against base.
+// Untracked files that are not ignored follow as new files.
+func Diff(dir, base string) (string, error) {
+	out, err := output(dir, "diff", "--no-color", "--no-ext-diff", base, "--")
+	if err != nil {
+		return "", err
+	}
+	root, err := RepoRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	untracked, err := output(root, "ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(out)
+	for _, f := range strings.Split(untracked, "\x00") {
+		if f == "" {
+			continue
+		}
+		d, err := newFileDiff(root, f)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(d)
+	}
+	return b.String(), nil
+}
+
+// newFileDiff returns the diff creating the untracked file path in root.
+func newFileDiff(root, path string) (string, error) {
+	cmd := exec.Command("git", "-C", root, "diff", "--no-color", "--no-ext-diff", "--no-index", "--", "/dev/null", path)
+	out, err := cmd.Output()
+	// --no-index exits with 1 when the files differ, which they always do.
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		return string(out), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("git diff failed for %s: %w", path, err)
+	}
+	return string(out), nil
+}
+
+// ApplyPatch applies patch to the repository containing dir: to the index
+// when cached is set, and in reverse when reverse is set. Paths in patch are
+// relative to the repository root, as Diff prints them; git apply run from a
+// subdirectory would skip files outside it without failing.
+func ApplyPatch(dir, patch string, cached, reverse bool) error {
+	root, err := RepoRoot(dir)
+	if err != nil {
+		return err
+	}
+	args := []string{"-C", root, "apply"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	if reverse {
+		args = append(args, "-R")
+	}
+	cmd := exec.Command("git", append(args, "-")...)
+	cmd.Stdin = strings.NewReader(patch)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git apply failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package model
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EventKind identifies what happened to a session.
+type EventKind string
+
+const (
+	EventLaunch   EventKind = "launch"   // tmux session created
+	EventAttach   EventKind = "attach"   // full tmux attach
+	EventInteract EventKind = "interact" // LIVE mode entered or a prompt sent
+	EventActive   EventKind = "active"   // Seconds of observed pane output
+)
+
+// Event is one line of the activity log.
+type Event struct {
+	At      time.Time `json:"at"`
+	Session string    `json:"session"` // Session.ID
+	Kind    EventKind `json:"kind"`
+	Seconds int64     `json:"seconds,omitempty"`
+}
+
+// Activity summarizes the events of one session.
+type Activity struct {
+	LastLaunched   time.Time
+	LastAttached   time.Time
+	LastInteracted time.Time
+	Launches       int
+	Attaches       int
+	Interactions   int
+	Active         time.Duration
+}
+
+// LastUsed returns the latest launch, attach or interaction, or the zero
+// time when the session was never used.
+func (a Activity) LastUsed() time.Time {
+	t := a.LastLaunched
+	for _, u := range []time.Time{a.LastAttached, a.LastInteracted} {
+		if u.After(t) {
+			t = u
+		}
+	}
+	return t
+}
+
+// ActivityLog is an append-only log of session events, persisted one JSON
+// object per line to ~/.config/claude-session-manager/activity.jsonl.
+type ActivityLog struct {
+	path      string
+	events    []Event
+	bySession map[string]*Activity
+}
+
+// LoadActivityLog reads the activity log. A missing file yields an empty
+// log; lines that cannot be parsed are skipped.
+func LoadActivityLog() (*ActivityLog, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	l := &ActivityLog{path: filepath.Join(dir, "activity.jsonl"), bySession: make(map[string]*Activity)}
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return l, fmt.Errorf("cannot read activity log: %w", err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e Event
+		if json.Unmarshal(sc.Bytes(), &e) == nil && e.Session != "" {
+			l.fold(e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return l, fmt.Errorf("cannot read activity log: %w", err)
+	}
+	return l, nil
+}
+
+func (l *ActivityLog) fold(e Event) {
+	l.events = append(l.events, e)
+	a := l.bySession[e.Session]
+	if a == nil {
+		a = &Activity{}
+		l.bySession[e.Session] = a
+	}
+	switch e.Kind {
+	case EventLaunch:
+		a.Launches++
+		a.LastLaunched = latest(a.LastLaunched, e.At)
+	case EventAttach:
+		a.Attaches++
+		a.LastAttached = latest(a.LastAttached, e.At)
+	case EventInteract:
+		a.Interactions++
+		a.LastInteracted = latest(a.LastInteracted, e.At)
+	case EventActive:
+		a.Active += time.Duration(e.Seconds) * time.Second
+	}
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// Record appends an event to the log. The in-memory summary is updated even
+// when writing the file fails.
+func (l *ActivityLog) Record(e Event) error {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	if l.bySession == nil {
+		l.bySession = make(map[string]*Activity)
+	}
+	l.fold(e)
+	if l.path == "" {
+		return nil
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("cannot marshal activity event: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot write activity log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("cannot write activity log: %w", err)
+	}
+	return nil
+}
+
+// For returns the activity summary of a session by Session.ID.
+func (l *ActivityLog) For(sessionID string) Activity {
+	if a := l.bySession[sessionID]; a != nil {
+		return *a
+	}
+	return Activity{}
+}
+
+// ActiveSince returns how long a session was active at or after since.
+func (l *ActivityLog) ActiveSince(sessionID string, since time.Time) time.Duration {
+	var d time.Duration
+	for _, e := range l.events {
+		if e.Session == sessionID && e.Kind == EventActive && !e.At.Before(since) {
+			d += time.Duration(e.Seconds) * time.Second
+		}
+	}
+	return d
+}
+
+// ActivePerDay returns the active time of the given sessions for each of the
+// last n days, oldest first; the last entry is today (local time).
+func (l *ActivityLog) ActivePerDay(sessionIDs map[string]bool, n int, now time.Time) []time.Duration {
+	days := make([]time.Duration, n)
+	y, mo, d := now.Date()
+	today := time.Date(y, mo, d, 0, 0, 0, 0, now.Location())
+	for _, e := range l.events {
+		if e.Kind != EventActive || !sessionIDs[e.Session] {
+			continue
+		}
+		at := e.At.In(now.Location())
+		ey, em, ed := at.Date()
+		age := int(today.Sub(time.Date(ey, em, ed, 0, 0, 0, 0, now.Location())).Hours()+12) / 24
+		if age >= 0 && age < n {
+			days[n-1-age] += time.Duration(e.Seconds) * time.Second
+		}
+	}
+	return days
+}
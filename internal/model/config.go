@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ThemeConfig describes a user-defined color theme. Colors override the
+// palette of the Base built-in theme by key (e.g. "accent": "#FF5F87").
+type ThemeConfig struct {
+	Base   string            `json:"base,omitempty"`
+	Colors map[string]string `json:"colors,omitempty"`
+}
+
+// Config holds user preferences read from config.json. Unlike data.json it is
+// never written by the TUI.
+type Config struct {
+	// Theme selects the color theme: "auto" (default), "dark", "light",
+	// "high-contrast", or the name of an entry in Themes.
+	Theme  string                 `json:"theme,omitempty"`
+	Themes map[string]ThemeConfig `json:"themes,omitempty"`
+
+	// StopTimeoutSeconds is how long a graceful stop waits for Claude to
+	// exit before the tmux session is killed (default 10).
+	StopTimeoutSeconds int `json:"stop_timeout_seconds,omitempty"`
+	// StopOnDelete also stops the tmux session when a session entry is
+	// deleted with 'y'.
+	StopOnDelete bool `json:"stop_on_delete,omitempty"`
+	// LaunchConcurrency caps how many sessions a group launch starts at
+	// once (default 3).
+	LaunchConcurrency int `json:"launch_concurrency,omitempty"`
+	// Prices overrides or extends the built-in price table used for usage
+	// costs. Keys match any model name containing them; the longest wins.
+	Prices map[string]Price `json:"prices,omitempty"`
+}
+
+// Price is what a model costs in US dollars per million tokens.
+type Price struct {
+	Input      float64 `json:"input"`
+	Output     float64 `json:"output"`
+	CacheWrite float64 `json:"cache_write"`
+	CacheRead  float64 `json:"cache_read"`
+}
+
+// defaultPrices are the published list prices at the time of writing.
+// The family keys ("opus", "sonnet", "haiku") price models not listed by
+// name; models priced differently from their family are keyed by their
+// exact names.
+var defaultPrices = map[string]Price{
+	"opus":        {Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.50},
+	"opus-4-1":    {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.50},
+	"opus-4-2025": {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.50},
+	"3-opus":      {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.50},
+	"sonnet":      {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30},
+	"haiku":       {Input: 0.80, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+	"haiku-4-5":   {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.10},
+	"3-haiku":     {Input: 0.25, Output: 1.25, CacheWrite: 0.30, CacheRead: 0.03},
+}
+
+// StopTimeout returns the graceful stop timeout.
+func (c *Config) StopTimeout() time.Duration {
+	if c == nil || c.StopTimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.StopTimeoutSeconds) * time.Second
+}
+
+// LaunchLimit returns the group launch concurrency.
+func (c *Config) LaunchLimit() int {
+	if c == nil || c.LaunchConcurrency <= 0 {
+		return 3
+	}
+	return c.LaunchConcurrency
+}
+
+// PriceFor returns the price of the named model. Configured prices take
+// precedence over the built-in ones for the same key.
+func (c *Config) PriceFor(modelName string) (Price, bool) {
+	var prices map[string]Price
+	if c != nil {
+		prices = c.Prices
+	}
+	best, found := "", false
+	var price Price
+	for _, table := range []map[string]Price{defaultPrices, prices} {
+		for key, p := range table {
+			if !strings.Contains(modelName, key) || len(key) < len(best) {
+				continue
+			}
+			best, price, found = key, p, true
+		}
+	}
+	return price, found
+}
+
+// LoadConfig reads ~/.config/claude-session-manager/config.json. A missing
+// file yields the zero Config.
+func LoadConfig() (*Config, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config file: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+	return cfg, nil
+}
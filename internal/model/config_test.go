@@ -0,0 +1,56 @@
+package model
+
+import "testing"
+
+func TestPriceFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		model  string
+		input  float64
+		priced bool
+	}{
+		{name: "current opus", model: "claude-opus-4-6", input: 5, priced: true},
+		{name: "opus 4.1", model: "claude-opus-4-1-20250805", input: 15, priced: true},
+		{name: "opus 4", model: "claude-opus-4-20250514", input: 15, priced: true},
+		{name: "claude 3 opus", model: "claude-3-opus-20240229", input: 15, priced: true},
+		{name: "sonnet", model: "claude-sonnet-4-5-20250929", input: 3, priced: true},
+		{name: "haiku 4.5", model: "claude-haiku-4-5-20251001", input: 1, priced: true},
+		{name: "haiku 3.5", model: "claude-3-5-haiku-20241022", input: 0.80, priced: true},
+		{name: "claude 3 haiku", model: "claude-3-haiku-20240307", input: 0.25, priced: true},
+		{name: "unknown model", model: "gpt-4o"},
+		{name: "nil config", model: "claude-sonnet-4-5", input: 3, priced: true},
+		{
+			name:   "configured key replaces the built-in one",
+			cfg:    &Config{Prices: map[string]Price{"sonnet": {Input: 2}}},
+			model:  "claude-sonnet-4-5",
+			input:  2,
+			priced: true,
+		},
+		{
+			name:   "configured key adds a model",
+			cfg:    &Config{Prices: map[string]Price{"mystery": {Input: 7}}},
+			model:  "mystery-model",
+			input:  7,
+			priced: true,
+		},
+		{
+			name:   "longer built-in key beats a shorter configured one",
+			cfg:    &Config{Prices: map[string]Price{"opus": {Input: 1}}},
+			model:  "claude-opus-4-1-20250805",
+			input:  15,
+			priced: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := tt.cfg.PriceFor(tt.model)
+			if ok != tt.priced {
+				t.Fatalf("priced = %v, want %v", ok, tt.priced)
+			}
+			if p.Input != tt.input {
+				t.Errorf("input price = %v, want %v", p.Input, tt.input)
+			}
+		})
+	}
+}
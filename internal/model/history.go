@@ -0,0 +1,65 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// maxHistory caps the number of prompts kept in the history file.
+const maxHistory = 100
+
+// PromptHistory keeps recently sent prompts, oldest first, persisted to
+// ~/.config/claude-session-manager/history.json.
+type PromptHistory struct {
+	path    string
+	Prompts []string `json:"prompts"`
+}
+
+// LoadPromptHistory reads the prompt history. A missing file yields an empty
+// history.
+func LoadPromptHistory() (*PromptHistory, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	h := &PromptHistory{path: filepath.Join(dir, "history.json")}
+	data, err := os.ReadFile(h.path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return h, fmt.Errorf("cannot read history file: %w", err)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return h, fmt.Errorf("invalid history file: %w", err)
+	}
+	return h, nil
+}
+
+// Add appends a prompt, moving an identical earlier entry to the end.
+func (h *PromptHistory) Add(prompt string) {
+	for i, p := range h.Prompts {
+		if p == prompt {
+			h.Prompts = append(h.Prompts[:i], h.Prompts[i+1:]...)
+			break
+		}
+	}
+	h.Prompts = append(h.Prompts, prompt)
+	if n := len(h.Prompts); n > maxHistory {
+		h.Prompts = h.Prompts[n-maxHistory:]
+	}
+}
+
+// Save writes the history to disk.
+func (h *PromptHistory) Save() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal history: %w", err)
+	}
+	return os.WriteFile(h.path, data, 0o644)
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+// Snippet is a named, reusable prompt. Text may contain {{name}} placeholders.
+type Snippet struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Text        string `json:"text"`
+}
+
+// Placeholders returns the distinct placeholder names in order of appearance.
+func (s Snippet) Placeholders() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderRe.FindAllStringSubmatch(s.Text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// Expand substitutes placeholders with values. Placeholders without a value
+// are left as-is.
+func (s Snippet) Expand(values map[string]string) string {
+	return placeholderRe.ReplaceAllStringFunc(s.Text, func(m string) string {
+		name := placeholderRe.FindStringSubmatch(m)[1]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		return m
+	})
+}
+
+// SnippetLibrary holds global snippets and per-group overrides, read from
+// ~/.config/claude-session-manager/snippets.json. Groups is keyed by group
+// path ("org/repo", see Store.GroupPath); a group snippet replaces a global
+// one with the same name.
+type SnippetLibrary struct {
+	path   string
+	Global []Snippet            `json:"global"`
+	Groups map[string][]Snippet `json:"groups,omitempty"`
+}
+
+// LoadSnippets reads the snippet library. A missing file yields an empty
+// library.
+func LoadSnippets() (*SnippetLibrary, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	l := &SnippetLibrary{path: filepath.Join(dir, "snippets.json")}
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read snippets file: %w", err)
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("invalid snippets file: %w", err)
+	}
+	return l, nil
+}
+
+// Path returns the location of the snippets file.
+func (l *SnippetLibrary) Path() string {
+	return l.path
+}
+
+// ForGroup returns the snippets visible in the group at path: global
+// snippets with group overrides applied in place, followed by group-only
+// snippets.
+func (l *SnippetLibrary) ForGroup(path string) []Snippet {
+	overrides := l.Groups[path]
+	byName := make(map[string]Snippet, len(overrides))
+	for _, s := range overrides {
+		byName[strings.ToLower(s.Name)] = s
+	}
+	var out []Snippet
+	for _, s := range l.Global {
+		if o, ok := byName[strings.ToLower(s.Name)]; ok {
+			out = append(out, o)
+			delete(byName, strings.ToLower(s.Name))
+			continue
+		}
+		out = append(out, s)
+	}
+	for _, s := range overrides {
+		if _, ok := byName[strings.ToLower(s.Name)]; ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
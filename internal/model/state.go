@@ -0,0 +1,62 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UIState holds view preferences and the cursor the TUI restores on
+// startup, persisted to ~/.config/claude-session-manager/state.json. It is
+// kept apart from data.json so that losing it never loses sessions.
+type UIState struct {
+	path string
+	// Sort is the tree sort mode: "manual" (default), "name", "activity",
+	// "created", "running" or "attention".
+	Sort string `json:"sort,omitempty"`
+	// View is "tree" (default) or "flat" for all sessions without groups.
+	View string `json:"view,omitempty"`
+
+	// Collapsed lists the IDs of collapsed groups; other groups, including
+	// ones created later, start expanded.
+	Collapsed []string `json:"collapsed,omitempty"`
+	// Group and Session are the IDs of the selected group and session;
+	// Session is empty when the cursor is on the group header.
+	Group   string `json:"group,omitempty"`
+	Session string `json:"session,omitempty"`
+	// Focus is the focused panel: "tree" (default) or "preview".
+	Focus string `json:"focus,omitempty"`
+}
+
+// LoadUIState reads the UI state. A missing file yields the zero state.
+func LoadUIState() (*UIState, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	st := &UIState{path: filepath.Join(dir, "state.json")}
+	data, err := os.ReadFile(st.path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return st, fmt.Errorf("cannot read state file: %w", err)
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return st, fmt.Errorf("invalid state file: %w", err)
+	}
+	return st, nil
+}
+
+// Save writes the UI state to disk.
+func (st *UIState) Save() error {
+	if st.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal state: %w", err)
+	}
+	return os.WriteFile(st.path, data, 0o644)
+}
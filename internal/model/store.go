@@ -7,34 +7,72 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
+// dataVersion is the data.json format this version writes. Version 2 added
+// nested groups; files without a version hold a flat list of groups.
+const dataVersion = 2
+
 // Store handles persistence of session and group data.
 type Store struct {
-	path string
-	Data AppData
+	path     string
+	migrated bool // Data was upgraded from an older format by Load
+	Data     AppData
 }
 
-// NewStore creates a Store that reads/writes to ~/.config/claude-session-manager/data.json.
-func NewStore() (*Store, error) {
-	home, err := os.UserHomeDir()
+// DataPath returns the path of data.json.
+func DataPath() (string, error) {
+	dir, err := ConfigDir()
 	if err != nil {
-		return nil, fmt.Errorf("cannot determine home directory: %w", err)
+		return "", err
 	}
-	dir := filepath.Join(home, ".config", "claude-session-manager")
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return nil, fmt.Errorf("cannot create config directory: %w", err)
+	return filepath.Join(dir, "data.json"), nil
+}
+
+// ExpandPath replaces a leading "~" with the user's home directory.
+func ExpandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + path[1:]
+		}
 	}
-	s := &Store{path: filepath.Join(dir, "data.json")}
+	return path
+}
+
+// OpenStore loads the data file at path without modifying it.
+func OpenStore(path string) (*Store, error) {
+	s := &Store{path: path}
 	if err := s.Load(); err != nil {
 		return nil, err
 	}
+	return s, nil
+}
+
+// NewStore creates a Store that reads/writes to ~/.config/claude-session-manager/data.json.
+func NewStore() (*Store, error) {
+	path, err := DataPath()
+	if err != nil {
+		return nil, err
+	}
+	s, err := OpenStore(path)
+	if err != nil {
+		return nil, err
+	}
+	if s.migrated {
+		if err := s.backup(); err != nil {
+			return nil, err
+		}
+		if err := s.Save(); err != nil {
+			return nil, fmt.Errorf("cannot save migrated data: %w", err)
+		}
+	}
 	// Clean up empty "Default" group if other groups exist
 	if len(s.Data.Groups) > 1 {
 		cleaned := make([]Group, 0, len(s.Data.Groups))
 		for _, g := range s.Data.Groups {
-			if g.Name == "Default" && len(g.Sessions) == 0 {
+			if g.Name == "Default" && len(g.Sessions) == 0 && len(s.ChildGroups(g.ID)) == 0 {
 				continue
 			}
 			cleaned = append(cleaned, g)
@@ -51,13 +89,138 @@ func NewStore() (*Store, error) {
 func (s *Store) Load() error {
 	data, err := os.ReadFile(s.path)
 	if os.IsNotExist(err) {
-		s.Data = AppData{Groups: []Group{}}
+		s.Data = AppData{Version: dataVersion, Groups: []Group{}}
 		return nil
 	}
 	if err != nil {
 		return fmt.Errorf("cannot read data file: %w", err)
 	}
-	return json.Unmarshal(data, &s.Data)
+	if err := json.Unmarshal(data, &s.Data); err != nil {
+		return err
+	}
+	s.migrated = s.migrate()
+	s.repairParents()
+	return nil
+}
+
+// backup copies the data file to data.json.v1.bak before it is rewritten in
+// the current format, so that an older version can still be run against the
+// copy. An existing backup is kept.
+func (s *Store) backup() error {
+	bak := s.path + ".v1.bak"
+	if _, err := os.Stat(bak); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("cannot back up data file: %w", err)
+	}
+	if err := os.WriteFile(bak, data, 0o644); err != nil {
+		return fmt.Errorf("cannot back up data file: %w", err)
+	}
+	return nil
+}
+
+// migrate upgrades data written by older versions and reports whether
+// anything changed. Flat groups named like paths ("org/repo") become nested
+// groups, which keeps the tmux names of their sessions unchanged.
+func (s *Store) migrate() bool {
+	if s.Data.Version >= dataVersion {
+		return false
+	}
+	flat := s.Data.Groups
+	s.Data.Groups = make([]Group, 0, len(flat))
+	var paths []Group
+	for _, g := range flat {
+		if strings.Contains(g.Name, "/") {
+			paths = append(paths, g)
+		} else {
+			s.Data.Groups = append(s.Data.Groups, g)
+		}
+	}
+	for _, g := range paths {
+		parts := splitGroupPath(g.Name)
+		if len(parts) == 0 {
+			continue
+		}
+		parent := ""
+		if len(parts) > 1 {
+			parent = s.Data.Groups[s.ensurePath(parts[:len(parts)-1])].ID
+		}
+		g.Name, g.Parent = parts[len(parts)-1], parent
+		s.Data.Groups = append(s.Data.Groups, g)
+	}
+	s.Data.Version = dataVersion
+	return true
+}
+
+// repairParents moves groups whose parent is missing, or that are part of
+// a parent cycle, to the top level.
+func (s *Store) repairParents() {
+	byID := make(map[string]int, len(s.Data.Groups))
+	for i, g := range s.Data.Groups {
+		byID[g.ID] = i
+	}
+	for i := range s.Data.Groups {
+		seen := map[int]bool{i: true}
+		for p := s.Data.Groups[i].Parent; p != ""; {
+			pi, ok := byID[p]
+			if !ok || seen[pi] {
+				s.Data.Groups[i].Parent = ""
+				break
+			}
+			seen[pi] = true
+			p = s.Data.Groups[pi].Parent
+		}
+	}
+}
+
+// splitGroupPath splits "org/repo/task" into its non-empty, trimmed names.
+func splitGroupPath(path string) []string {
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// CleanGroupPath normalizes a "/"-separated group path the way
+// EnsureGroupPath reads it: " org / repo/" becomes "org/repo".
+func CleanGroupPath(path string) string {
+	return strings.Join(splitGroupPath(path), "/")
+}
+
+// ensurePath returns the index of the group at the end of parts, creating
+// missing groups along the way.
+func (s *Store) ensurePath(parts []string) int {
+	idx, parent := -1, ""
+	for _, name := range parts {
+		idx = -1
+		for i, g := range s.Data.Groups {
+			if g.Parent == parent && g.Name == name {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			idx = s.AddGroup(name)
+			s.Data.Groups[idx].Parent = parent
+		}
+		parent = s.Data.Groups[idx].ID
+	}
+	return idx
+}
+
+// EnsureGroupPath returns the index of the group at a "/"-separated path
+// such as "org/repo/task", creating missing groups, or -1 for an empty path.
+func (s *Store) EnsureGroupPath(path string) int {
+	parts := splitGroupPath(path)
+	if len(parts) == 0 {
+		return -1
+	}
+	return s.ensurePath(parts)
 }
 
 // Save writes current data to disk.
@@ -81,12 +244,96 @@ func (s *Store) AddGroup(name string) int {
 	return len(s.Data.Groups) - 1
 }
 
-// DeleteGroup removes a group by index.
+// AddSubgroup creates a group inside the group at parentIdx and returns its
+// index.
+func (s *Store) AddSubgroup(parentIdx int, name string) int {
+	idx := s.AddGroup(name)
+	if parentIdx >= 0 && parentIdx < len(s.Data.Groups) {
+		s.Data.Groups[idx].Parent = s.Data.Groups[parentIdx].ID
+	}
+	return idx
+}
+
+// DeleteGroup removes a group by index, together with its subgroups.
 func (s *Store) DeleteGroup(idx int) {
 	if idx < 0 || idx >= len(s.Data.Groups) {
 		return
 	}
-	s.Data.Groups = append(s.Data.Groups[:idx], s.Data.Groups[idx+1:]...)
+	doomed := make(map[int]bool)
+	for _, i := range s.Subtree(idx) {
+		doomed[i] = true
+	}
+	kept := s.Data.Groups[:0]
+	for i, g := range s.Data.Groups {
+		if !doomed[i] {
+			kept = append(kept, g)
+		}
+	}
+	s.Data.Groups = kept
+}
+
+// ChildGroups returns the indexes of the groups directly inside the group
+// with the given ID ("" for top-level groups), in stored order.
+func (s *Store) ChildGroups(parentID string) []int {
+	var children []int
+	for i, g := range s.Data.Groups {
+		if g.Parent == parentID {
+			children = append(children, i)
+		}
+	}
+	return children
+}
+
+// Subtree returns idx followed by the indexes of all groups nested in it.
+func (s *Store) Subtree(idx int) []int {
+	if idx < 0 || idx >= len(s.Data.Groups) {
+		return nil
+	}
+	tree := []int{idx}
+	for i := 0; i < len(tree); i++ {
+		tree = append(tree, s.ChildGroups(s.Data.Groups[tree[i]].ID)...)
+	}
+	return tree
+}
+
+// GroupPath returns the names from the top-level group down to idx joined
+// by "/". For a top-level group it is just its name.
+func (s *Store) GroupPath(idx int) string {
+	if idx < 0 || idx >= len(s.Data.Groups) {
+		return ""
+	}
+	names := []string{s.Data.Groups[idx].Name}
+	for p := s.Data.Groups[idx].Parent; p != ""; {
+		pi := s.groupByID(p)
+		if pi < 0 {
+			break
+		}
+		names = append([]string{s.Data.Groups[pi].Name}, names...)
+		p = s.Data.Groups[pi].Parent
+	}
+	return strings.Join(names, "/")
+}
+
+// GroupDepth returns how many groups enclose idx; 0 for top-level groups.
+func (s *Store) GroupDepth(idx int) int {
+	depth := 0
+	for p := s.Data.Groups[idx].Parent; p != ""; depth++ {
+		pi := s.groupByID(p)
+		if pi < 0 {
+			break
+		}
+		p = s.Data.Groups[pi].Parent
+	}
+	return depth
+}
+
+func (s *Store) groupByID(id string) int {
+	for i, g := range s.Data.Groups {
+		if g.ID == id {
+			return i
+		}
+	}
+	return -1
 }
 
 // AddSession adds a session to a group and returns the session index.
@@ -118,6 +365,17 @@ func (s *Store) DeleteSession(groupIdx, sessIdx int) {
 	g.Sessions = append(g.Sessions[:sessIdx], g.Sessions[sessIdx+1:]...)
 }
 
+// maxBroadcasts caps the broadcast history kept in data.json.
+const maxBroadcasts = 50
+
+// AddBroadcast records a broadcast, dropping the oldest beyond maxBroadcasts.
+func (s *Store) AddBroadcast(b Broadcast) {
+	s.Data.Broadcasts = append(s.Data.Broadcasts, b)
+	if n := len(s.Data.Broadcasts); n > maxBroadcasts {
+		s.Data.Broadcasts = s.Data.Broadcasts[n-maxBroadcasts:]
+	}
+}
+
 // Groups returns all groups.
 func (s *Store) Groups() []Group {
 	return s.Data.Groups
@@ -131,6 +389,19 @@ func (s *Store) Sessions(groupIdx int) []Session {
 	return s.Data.Groups[groupIdx].Sessions
 }
 
+// ConfigDir returns ~/.config/claude-session-manager, creating it if needed.
+func ConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".config", "claude-session-manager")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("cannot create config directory: %w", err)
+	}
+	return dir, nil
+}
+
 func genID() string {
 	b := make([]byte, 8)
 	_, _ = rand.Read(b)
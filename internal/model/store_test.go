@@ -0,0 +1,164 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLoadMigratesAndRepairsGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		groups   []Group
+		migrated bool
+		paths    []string
+	}{
+		{
+			name:     "flat names stay top level",
+			groups:   []Group{{ID: "a", Name: "work"}, {ID: "b", Name: "home"}},
+			migrated: true,
+			paths:    []string{"home", "work"},
+		},
+		{
+			name:     "path names become nested groups",
+			groups:   []Group{{ID: "a", Name: "org/repo"}, {ID: "b", Name: "org/other/task"}},
+			migrated: true,
+			paths:    []string{"org", "org/other", "org/other/task", "org/repo"},
+		},
+		{
+			name:     "path names reuse an existing top-level group",
+			groups:   []Group{{ID: "a", Name: "org/repo"}, {ID: "b", Name: "org"}},
+			migrated: true,
+			paths:    []string{"org", "org/repo"},
+		},
+		{
+			name:     "blank path segments are dropped",
+			groups:   []Group{{ID: "a", Name: " org / /repo/"}},
+			migrated: true,
+			paths:    []string{"org", "org/repo"},
+		},
+		{
+			name:    "current version is left alone",
+			version: dataVersion,
+			groups:  []Group{{ID: "a", Name: "a/b"}},
+			paths:   []string{"a/b"},
+		},
+		{
+			name:    "missing parent moves to top level",
+			version: dataVersion,
+			groups:  []Group{{ID: "a", Name: "child", Parent: "gone"}},
+			paths:   []string{"child"},
+		},
+		{
+			name:    "parent cycle is broken",
+			version: dataVersion,
+			groups: []Group{
+				{ID: "a", Name: "x", Parent: "b"},
+				{ID: "b", Name: "y", Parent: "a"},
+			},
+			paths: []string{"x", "x/y"},
+		},
+		{
+			name:    "self parent moves to top level",
+			version: dataVersion,
+			groups:  []Group{{ID: "a", Name: "x", Parent: "a"}},
+			paths:   []string{"x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeData(t, AppData{Version: tt.version, Groups: tt.groups})
+			s, err := OpenStore(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.migrated != tt.migrated {
+				t.Errorf("migrated = %v, want %v", s.migrated, tt.migrated)
+			}
+			if s.Data.Version != dataVersion {
+				t.Errorf("version = %d, want %d", s.Data.Version, dataVersion)
+			}
+			var paths []string
+			for i := range s.Data.Groups {
+				paths = append(paths, s.GroupPath(i))
+			}
+			sort.Strings(paths)
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("paths = %q, want %q", paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestLoadMigrationKeepsGroupIDs(t *testing.T) {
+	path := writeData(t, AppData{Groups: []Group{
+		{ID: "a", Name: "org/repo", Sessions: []Session{{ID: "s1", Name: "one"}}},
+	}})
+	s, err := OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := s.groupByID("a")
+	if i < 0 {
+		t.Fatal("group a lost in migration")
+	}
+	if got := s.GroupPath(i); got != "org/repo" {
+		t.Errorf("path = %q, want %q", got, "org/repo")
+	}
+	if n := len(s.Data.Groups[i].Sessions); n != 1 {
+		t.Errorf("sessions = %d, want 1", n)
+	}
+}
+
+func TestNewStoreBacksUpBeforeMigrating(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "claude-session-manager")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	v1 := []byte(`{"groups":[{"id":"a","name":"org/repo","sessions":[]}]}`)
+	path := filepath.Join(dir, "data.json")
+	if err := os.WriteFile(path, v1, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewStore(); err != nil {
+		t.Fatal(err)
+	}
+	bak, err := os.ReadFile(path + ".v1.bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bak) != string(v1) {
+		t.Errorf("backup = %s, want %s", bak, v1)
+	}
+	var saved AppData
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Version != dataVersion {
+		t.Errorf("saved version = %d, want %d", saved.Version, dataVersion)
+	}
+}
+
+func writeData(t *testing.T, d AppData) string {
+	t.Helper()
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
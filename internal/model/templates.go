@@ -0,0 +1,195 @@
+package model
+
+import (
+	"crypto/rand"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Template is a saved starting point for new sessions: everything about a
+// session except its name and Claude conversation.
+type Template struct {
+	Name string            `json:"name"`
+	Path string            `json:"path"`
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"`
+	Tags []string          `json:"tags,omitempty"`
+}
+
+// TemplateFrom captures the settings of a session under a template name.
+func TemplateFrom(name string, s Session) Template {
+	return Template{
+		Name: name,
+		Path: s.Path,
+		Args: append([]string(nil), s.Args...),
+		Env:  copyEnv(s.Env),
+		Tags: append([]string(nil), s.Tags...),
+	}
+}
+
+// Apply copies the template's launch args, env and tags to a session.
+func (t Template) Apply(s *Session) {
+	s.Args = append([]string(nil), t.Args...)
+	s.Env = copyEnv(t.Env)
+	s.Tags = append([]string(nil), t.Tags...)
+}
+
+// Summary describes the template's settings in one line.
+func (t Template) Summary() string {
+	var parts []string
+	if len(t.Args) > 0 {
+		parts = append(parts, JoinWords(t.Args))
+	}
+	if len(t.Env) > 0 {
+		parts = append(parts, FormatEnv(t.Env))
+	}
+	if len(t.Tags) > 0 {
+		parts = append(parts, "#"+strings.Join(t.Tags, " #"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func copyEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		out[k] = v
+	}
+	return out
+}
+
+// SaveTemplate adds a template, replacing one with the same name.
+func (s *Store) SaveTemplate(t Template) {
+	for i, old := range s.Data.Templates {
+		if old.Name == t.Name {
+			s.Data.Templates[i] = t
+			return
+		}
+	}
+	s.Data.Templates = append(s.Data.Templates, t)
+}
+
+// DeleteTemplate removes the template with the given name.
+func (s *Store) DeleteTemplate(name string) {
+	for i, t := range s.Data.Templates {
+		if t.Name == name {
+			s.Data.Templates = append(s.Data.Templates[:i], s.Data.Templates[i+1:]...)
+			return
+		}
+	}
+}
+
+// ParseEnv reads "KEY=value" pairs separated by spaces. Values with spaces
+// are quoted as in the shell (see SplitWords).
+func ParseEnv(text string) (map[string]string, error) {
+	words, err := SplitWords(text)
+	if err != nil {
+		return nil, err
+	}
+	var env map[string]string
+	for _, f := range words {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid env entry %q: use KEY=value", f)
+		}
+		if env == nil {
+			env = make(map[string]string)
+		}
+		env[k] = v
+	}
+	return env, nil
+}
+
+// FormatEnv renders env as sorted "KEY=value" pairs, the form ParseEnv reads.
+func FormatEnv(env map[string]string) string {
+	pairs := make([]string, 0, len(env))
+	for k, v := range env {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return JoinWords(pairs)
+}
+
+// SplitWords splits text into words the way a shell does: single quotes
+// keep their content as is, double quotes allow \" and \\ escapes, and a
+// backslash outside quotes escapes the next character.
+func SplitWords(text string) ([]string, error) {
+	var words []string
+	var w strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range text {
+		switch {
+		case escaped:
+			w.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				w.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				w.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, w.String())
+				w.Reset()
+				inWord = false
+			}
+		default:
+			w.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", text)
+	}
+	if inWord {
+		words = append(words, w.String())
+	}
+	return words, nil
+}
+
+// JoinWords renders words in the form SplitWords reads, single-quoting
+// those that are not plain.
+func JoinWords(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if plainWordRe.MatchString(w) {
+			quoted[i] = w
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// plainWordRe matches words that JoinWords leaves unquoted.
+var plainWordRe = regexp.MustCompile(`^[a-zA-Z0-9_./:=@%+,-]+$`)
+
+// NewUUID returns a random (version 4) UUID, the form Claude session IDs
+// take.
+func NewUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "  \t\n ", want: nil},
+		{in: "--model opus", want: []string{"--model", "opus"}},
+		{in: "  a   b  ", want: []string{"a", "b"}},
+		{in: `'a b' c`, want: []string{"a b", "c"}},
+		{in: `"a b" c`, want: []string{"a b", "c"}},
+		{in: `'a "b" \c'`, want: []string{`a "b" \c`}},
+		{in: `"say \"hi\" \\ now"`, want: []string{`say "hi" \ now`}},
+		{in: `a\ b`, want: []string{"a b"}},
+		{in: `\'`, want: []string{"'"}},
+		{in: `'it'\''s'`, want: []string{"it's"}},
+		{in: `it'\''s`, wantErr: true},
+		{in: `''`, want: []string{""}},
+		{in: `a '' b`, want: []string{"a", "", "b"}},
+		{in: `"" ""`, want: []string{"", ""}},
+		{in: `pre'mid'post`, want: []string{"premidpost"}},
+		{in: `KEY="a b"`, want: []string{"KEY=a b"}},
+		{in: `'unterminated`, wantErr: true},
+		{in: `"unterminated`, wantErr: true},
+		{in: `trailing\`, wantErr: true},
+		{in: `"escape at end\`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := SplitWords(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SplitWords(%q) = %q, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitWords(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinWords(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{words: nil, want: ""},
+		{words: []string{"--model", "opus"}, want: "--model opus"},
+		{words: []string{"KEY=a/b:c@d%e+f,g"}, want: "KEY=a/b:c@d%e+f,g"},
+		{words: []string{"a b"}, want: "'a b'"},
+		{words: []string{""}, want: "''"},
+		{words: []string{"it's"}, want: `'it'\''s'`},
+		{words: []string{`"$HOME"`}, want: `'"$HOME"'`},
+	}
+	for _, tt := range tests {
+		if got := JoinWords(tt.words); got != tt.want {
+			t.Errorf("JoinWords(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestJoinWordsRoundTrip(t *testing.T) {
+	tests := [][]string{
+		{"--model", "opus"},
+		{"--append-system-prompt", "Be brief. Don't guess."},
+		{""},
+		{"a", "", "b"},
+		{"'", "''", `"`, `\`, `\\'`},
+		{"tab\there", "new\nline", "  spaced  "},
+		{"$HOME", "*", "a;b", "`cmd`", "é ü"},
+	}
+	for _, words := range tests {
+		got, err := SplitWords(JoinWords(words))
+		if err != nil {
+			t.Fatalf("SplitWords(JoinWords(%q)): %v", words, err)
+		}
+		if !reflect.DeepEqual(got, words) {
+			t.Errorf("SplitWords(JoinWords(%q)) = %q", words, got)
+		}
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	env, err := ParseEnv(`A=1 B='two words' C= D="x=y"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"A": "1", "B": "two words", "C": "", "D": "x=y"}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("ParseEnv = %q, want %q", env, want)
+	}
+	if back, err := ParseEnv(FormatEnv(env)); err != nil || !reflect.DeepEqual(back, env) {
+		t.Errorf("ParseEnv(FormatEnv) = %q, %v", back, err)
+	}
+	for _, bad := range []string{"NOEQUALS", "=value", "A='open"} {
+		if _, err := ParseEnv(bad); err == nil {
+			t.Errorf("ParseEnv(%q) succeeded, want an error", bad)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Session represents a Claude Code session with its project context.
 type Session struct {
@@ -9,17 +12,74 @@ type Session struct {
 	SessionID string    `json:"session_id"`
 	Path      string    `json:"path"`
 	CreatedAt time.Time `json:"created_at"`
+	Pinned    bool      `json:"pinned,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	// PinnedAt orders the Pinned section; zero for sessions pinned by older
+	// versions, which keep their stored order at its top.
+	PinnedAt time.Time `json:"pinned_at,omitzero"`
+	// WorktreeOf is the repository Path was created from as a git worktree
+	// by ccdeck; empty for ordinary sessions.
+	WorktreeOf string `json:"worktree_of,omitempty"`
+	// BaseCommit is HEAD of Path when the session was last launched; the
+	// diff viewer compares against it.
+	BaseCommit string `json:"base_commit,omitempty"`
+	// Archived sessions are kept, with their Claude session ID, but hidden
+	// from their group and never launched until restored.
+	Archived   bool      `json:"archived,omitempty"`
+	ArchivedAt time.Time `json:"archived_at,omitzero"`
+
+	// Args are extra claude arguments and Env extra environment variables
+	// for the tmux session; both apply from the next launch.
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"`
+	// Fresh marks a SessionID chosen by ccdeck for a conversation that may
+	// not exist yet. Until its transcript appears, launching starts it with
+	// --session-id instead of resuming it, forking ForkOf when set; both are
+	// cleared once it does.
+	Fresh  bool   `json:"fresh,omitempty"`
+	ForkOf string `json:"fork_of,omitempty"`
+}
+
+// HasTag reports whether the session carries the given tag (case-insensitive).
+func (s Session) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
 }
 
-// Group organizes related sessions together.
+// Group organizes related sessions together. Groups nest: Parent is the ID
+// of the enclosing group, empty for top-level groups.
 type Group struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
+	Parent    string    `json:"parent,omitempty"`
 	Sessions  []Session `json:"sessions"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// BroadcastTarget records the outcome of a broadcast for one session.
+type BroadcastTarget struct {
+	SessionID string `json:"session_id"` // Session.ID
+	Name      string `json:"name"`
+	Sent      bool   `json:"sent"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Broadcast is a prompt sent to several sessions at once.
+type Broadcast struct {
+	Prompt  string            `json:"prompt"`
+	SentAt  time.Time         `json:"sent_at"`
+	Targets []BroadcastTarget `json:"targets"`
+}
+
 // AppData is the top-level data structure persisted to disk.
 type AppData struct {
-	Groups []Group `json:"groups"`
+	// Version is the format of the file; see dataVersion.
+	Version    int         `json:"version,omitempty"`
+	Groups     []Group     `json:"groups"`
+	Broadcasts []Broadcast `json:"broadcasts,omitempty"`
+	Templates  []Template  `json:"templates,omitempty"`
 }
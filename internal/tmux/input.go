@@ -0,0 +1,341 @@
+package tmux
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// controlTimeout bounds how long a batch waits for control-mode replies.
+const controlTimeout = 2 * time.Second
+
+var errControlClosed = errors.New("tmux control connection closed")
+
+// errControlExited reports a control client that went away before running
+// any command of a batch, so the batch can be sent again another way.
+var errControlExited = errors.New("tmux control client exited")
+
+// KeyKind says how an InputQueue delivers a Key.
+type KeyKind int
+
+const (
+	// KeyLiteral is text typed as-is (send-keys -l).
+	KeyLiteral KeyKind = iota
+	// KeySpecial is a named tmux key such as "Enter" or "C-c".
+	KeySpecial
+	// KeyPaste is text delivered as one bracketed paste (see PasteText).
+	KeyPaste
+)
+
+// Key is one item in an InputQueue.
+type Key struct {
+	Kind KeyKind
+	Text string
+}
+
+// FlushResult describes one batch written by InputQueue.Flush.
+type FlushResult struct {
+	Keys     int // keystrokes taken from the queue for this batch
+	Commands int // tmux commands the batch was coalesced into
+	Err      error
+}
+
+// InputQueue forwards keystrokes to one tmux session in strict order.
+// Enqueue is cheap and never blocks on tmux; Flush drains everything queued
+// so far, coalescing runs of literal text into a single send-keys. Batches
+// go over a persistent control-mode client when one can be attached, and
+// fall back to one tmux process per batch otherwise.
+type InputQueue struct {
+	target string
+
+	mu      sync.Mutex
+	pending []Key
+
+	flushMu   sync.Mutex // serializes batches so they reach tmux in order
+	conn      *controlConn
+	noControl bool
+}
+
+// NewInputQueue returns a queue for the tmux session target.
+func NewInputQueue(target string) *InputQueue {
+	return &InputQueue{target: target}
+}
+
+// Target returns the tmux session the queue writes to.
+func (q *InputQueue) Target() string {
+	return q.target
+}
+
+// Enqueue appends a keystroke and returns the number of keys waiting.
+func (q *InputQueue) Enqueue(k Key) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, k)
+	return len(q.pending)
+}
+
+// Pending returns the number of keys not yet flushed.
+func (q *InputQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Flush sends all queued keys. Concurrent calls are serialized; a call that
+// finds the queue already drained returns a zero FlushResult. Delivery stops
+// at the first error and the rest of the batch is dropped.
+func (q *InputQueue) Flush() FlushResult {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	q.mu.Lock()
+	batch := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+	if len(batch) == 0 {
+		return FlushResult{}
+	}
+
+	res := FlushResult{Keys: len(batch)}
+	// Pastes go through a paste buffer, so they split the batch into
+	// send-keys segments that are delivered around them in order.
+	start := 0
+	for i := 0; i <= len(batch); i++ {
+		if i < len(batch) && batch[i].Kind != KeyPaste {
+			continue
+		}
+		if i > start {
+			cmds := coalesce(q.target, batch[start:i])
+			res.Commands += len(cmds)
+			if res.Err = q.send(cmds); res.Err != nil {
+				return res
+			}
+		}
+		if i < len(batch) {
+			res.Commands++
+			if res.Err = PasteText(q.target, batch[i].Text); res.Err != nil {
+				return res
+			}
+		}
+		start = i + 1
+	}
+	return res
+}
+
+func (q *InputQueue) send(cmds [][]string) error {
+	if conn := q.control(); conn != nil {
+		err := conn.run(cmds)
+		if err == nil {
+			return nil
+		}
+		// Replies can no longer be matched to commands; drop the client.
+		// Whatever part of the batch tmux already ran is not retried.
+		q.closeControl()
+		if !errors.Is(err, errControlExited) {
+			return err
+		}
+		// The client never ran the batch, typically because tmux refused
+		// the attach (older than 3.2). Stop dialing and send it by exec.
+		q.noControl = true
+	}
+	return execBatch(cmds)
+}
+
+// Close detaches the control-mode client, if any.
+func (q *InputQueue) Close() {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+	q.closeControl()
+}
+
+func (q *InputQueue) control() *controlConn {
+	if q.conn == nil && !q.noControl {
+		conn, err := dialControl(q.target)
+		if err != nil {
+			q.noControl = true
+			return nil
+		}
+		q.conn = conn
+	}
+	return q.conn
+}
+
+func (q *InputQueue) closeControl() {
+	if q.conn != nil {
+		q.conn.close()
+		q.conn = nil
+	}
+}
+
+// coalesce turns keys into send-keys argument lists: adjacent literals are
+// joined into one -l command and adjacent special keys share a command.
+func coalesce(target string, keys []Key) [][]string {
+	var cmds [][]string
+	var text strings.Builder
+	var specials []string
+	flushText := func() {
+		if text.Len() > 0 {
+			// "--" keeps text starting with "-" from being read as flags.
+			cmds = append(cmds, []string{"send-keys", "-t", target, "-l", "--", text.String()})
+			text.Reset()
+		}
+	}
+	flushSpecials := func() {
+		if len(specials) > 0 {
+			cmds = append(cmds, append([]string{"send-keys", "-t", target}, specials...))
+			specials = nil
+		}
+	}
+	for _, k := range keys {
+		if k.Kind == KeySpecial {
+			flushText()
+			specials = append(specials, k.Text)
+		} else {
+			flushSpecials()
+			text.WriteString(k.Text)
+		}
+	}
+	flushText()
+	flushSpecials()
+	return cmds
+}
+
+// execBatch runs all commands in one tmux process, chained with ";".
+func execBatch(cmds [][]string) error {
+	var args []string
+	for i, c := range cmds {
+		if i > 0 {
+			args = append(args, ";")
+		}
+		args = append(args, c...)
+	}
+	out, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("send-keys failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Control mode
+// ---------------------------------------------------------------------------
+
+// controlConn is a tmux control-mode client (tmux -C) used to run commands
+// without spawning a process per command.
+type controlConn struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	replies chan error
+}
+
+func dialControl(target string) (*controlConn, error) {
+	cmd := exec.Command("tmux", "-C", "attach-session", "-t", target, "-f", "ignore-size,no-output")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("tmux control mode failed: %w", err)
+	}
+	c := &controlConn{cmd: cmd, stdin: stdin, replies: make(chan error, 64)}
+	go c.read(stdout)
+	return c, nil
+}
+
+// read parses %begin/%end/%error blocks. Only blocks flagged as replies to
+// this client's commands (flags 1) are delivered; notifications and the
+// reply to the initial attach are skipped.
+func (c *controlConn) read(r io.Reader) {
+	defer close(c.replies)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	inReply := false
+	var body []string
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "%begin "):
+			inReply = strings.HasSuffix(line, " 1")
+			body = nil
+		case strings.HasPrefix(line, "%end "):
+			if inReply {
+				c.replies <- nil
+			}
+			inReply = false
+		case strings.HasPrefix(line, "%error "):
+			if inReply {
+				c.replies <- errors.New(strings.Join(body, "; "))
+			}
+			inReply = false
+		case inReply:
+			body = append(body, line)
+		}
+	}
+}
+
+// run writes one command per line and waits for a reply to each, returning
+// the first error.
+func (c *controlConn) run(cmds [][]string) error {
+	var b strings.Builder
+	for _, args := range cmds {
+		for i, a := range args {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(quoteArg(a))
+		}
+		b.WriteByte('\n')
+	}
+	if _, err := io.WriteString(c.stdin, b.String()); err != nil {
+		return fmt.Errorf("%w: write failed: %v", errControlExited, err)
+	}
+
+	var first error
+	deadline := time.After(controlTimeout)
+	for i := range cmds {
+		select {
+		case err, ok := <-c.replies:
+			if !ok && i == 0 {
+				return errControlExited
+			}
+			if !ok {
+				return errControlClosed
+			}
+			if err != nil && first == nil {
+				first = fmt.Errorf("send-keys failed: %w", err)
+			}
+		case <-deadline:
+			return errors.New("tmux control reply timed out")
+		}
+	}
+	return first
+}
+
+func (c *controlConn) close() {
+	_ = c.stdin.Close()
+	done := make(chan struct{})
+	go func() {
+		_ = c.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(controlTimeout):
+		_ = c.cmd.Process.Kill()
+	}
+}
+
+// quoteArg single-quotes s for the tmux command parser. Embedded single
+// quotes are closed, emitted in double quotes, and reopened.
+func quoteArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,70 @@
+package tmux
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoalesce(t *testing.T) {
+	lit := func(s string) Key { return Key{Kind: KeyLiteral, Text: s} }
+	special := func(s string) Key { return Key{Kind: KeySpecial, Text: s} }
+	tests := []struct {
+		name string
+		keys []Key
+		want [][]string
+	}{
+		{name: "empty", keys: nil, want: nil},
+		{
+			name: "literal run is joined",
+			keys: []Key{lit("h"), lit("i"), lit(" "), lit("!")},
+			want: [][]string{{"send-keys", "-t", "s", "-l", "--", "hi !"}},
+		},
+		{
+			name: "special keys share a command",
+			keys: []Key{special("Up"), special("Up"), special("Enter")},
+			want: [][]string{{"send-keys", "-t", "s", "Up", "Up", "Enter"}},
+		},
+		{
+			name: "order is kept across kinds",
+			keys: []Key{lit("a"), lit("b"), special("BSpace"), lit("c"), special("Enter")},
+			want: [][]string{
+				{"send-keys", "-t", "s", "-l", "--", "ab"},
+				{"send-keys", "-t", "s", "BSpace"},
+				{"send-keys", "-t", "s", "-l", "--", "c"},
+				{"send-keys", "-t", "s", "Enter"},
+			},
+		},
+		{
+			name: "text starting with a dash stays literal",
+			keys: []Key{lit("-"), lit("v")},
+			want: [][]string{{"send-keys", "-t", "s", "-l", "--", "-v"}},
+		},
+		{
+			name: "quotes and semicolons are passed through",
+			keys: []Key{lit(`'";`)},
+			want: [][]string{{"send-keys", "-t", "s", "-l", "--", `'";`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coalesce("s", tt.keys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coalesce = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteArg(t *testing.T) {
+	tests := map[string]string{
+		"":      "''",
+		"plain": "'plain'",
+		"a b":   "'a b'",
+		"it's":  `'it'"'"'s'`,
+		`a;"b"`: `'a;"b"'`,
+	}
+	for in, want := range tests {
+		if got := quoteArg(in); got != want {
+			t.Errorf("quoteArg(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
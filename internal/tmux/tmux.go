@@ -1,17 +1,34 @@
 package tmux
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var safeNameRe = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
 
+// SessionPrefix starts the name of every tmux session created by SanitizeName.
+const SessionPrefix = "claude_"
+
+// resumeRe and startRe extract the Claude session ID from a pane start
+// command built by NewSession.
+var (
+	resumeRe = regexp.MustCompile(`claude -r '?([^'"\s]+)`)
+	startRe  = regexp.MustCompile(`claude .*--session-id '?([^'"\s]+)`)
+)
+
+// safeArgRe matches shell words that need no quoting.
+var safeArgRe = regexp.MustCompile(`^[a-zA-Z0-9_./:=@%+,-]+$`)
+
 // SanitizeName converts a string into a valid tmux session name.
 func SanitizeName(group, session string) string {
-	name := fmt.Sprintf("claude_%s_%s", group, session)
+	name := fmt.Sprintf("%s%s_%s", SessionPrefix, group, session)
 	name = safeNameRe.ReplaceAllString(name, "_")
 	name = strings.Trim(name, "_")
 	if len(name) > 64 {
@@ -26,16 +43,74 @@ func IsInstalled() bool {
 	return err == nil
 }
 
+// Version returns the output of tmux -V, e.g. "tmux 3.3a".
+func Version() (string, error) {
+	out, err := exec.Command("tmux", "-V").Output()
+	if err != nil {
+		return "", fmt.Errorf("tmux -V failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ServerRunning reports whether a tmux server is reachable. A missing server
+// is not an error; any other failure to talk to it is.
+func ServerRunning() (bool, error) {
+	out, err := exec.Command("tmux", "list-sessions").CombinedOutput()
+	if err == nil {
+		return true, nil
+	}
+	msg := strings.TrimSpace(string(out))
+	if strings.Contains(msg, "no server running") || strings.Contains(msg, "error connecting") {
+		return false, nil
+	}
+	return false, fmt.Errorf("tmux list-sessions failed: %s: %w", msg, err)
+}
+
 // SessionExists checks if a tmux session with the given name is running.
 func SessionExists(name string) bool {
 	cmd := exec.Command("tmux", "has-session", "-t", name)
 	return cmd.Run() == nil
 }
 
-// NewSession creates a detached tmux session running claude with the given session ID.
-func NewSession(name, workdir, claudeSessionID string) error {
-	shellCmd := fmt.Sprintf("claude -r %s", claudeSessionID)
-	args := []string{"new-session", "-d", "-s", name, "-c", workdir, shellCmd}
+// Launch describes the claude command a new tmux session runs.
+type Launch struct {
+	Resume string            // Claude session ID to resume with -r; empty starts a new conversation
+	Args   []string          // further claude arguments
+	Env    map[string]string // extra environment variables
+}
+
+// Command returns the shell command that starts claude.
+func (l Launch) Command() string {
+	words := []string{"claude"}
+	if l.Resume != "" {
+		words = append(words, "-r", shellQuote(l.Resume))
+	}
+	for _, a := range l.Args {
+		words = append(words, shellQuote(a))
+	}
+	return strings.Join(words, " ")
+}
+
+// shellQuote single-quotes s for the shell unless it is a plain word.
+func shellQuote(s string) string {
+	if safeArgRe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// NewSession creates a detached tmux session running claude as described by l.
+func NewSession(name, workdir string, l Launch) error {
+	args := []string{"new-session", "-d", "-s", name, "-c", workdir}
+	keys := make([]string, 0, len(l.Env))
+	for k := range l.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+l.Env[k])
+	}
+	args = append(args, l.Command())
 	cmd := exec.Command("tmux", args...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -80,6 +155,97 @@ func ListSessions() ([]string, error) {
 	return result, nil
 }
 
+// ListActivity returns every running tmux session with the time its active
+// window last produced output.
+func ListActivity() (map[string]time.Time, error) {
+	out, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name} #{window_activity}").Output()
+	if err != nil {
+		if strings.Contains(err.Error(), "no server running") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	result := make(map[string]time.Time)
+	for _, l := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// The name may contain spaces; the timestamp never does.
+		l = strings.TrimSpace(l)
+		i := strings.LastIndex(l, " ")
+		if i < 0 {
+			continue
+		}
+		name, ts := l[:i], l[i+1:]
+		var t time.Time
+		if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			t = time.Unix(sec, 0)
+		}
+		result[name] = t
+	}
+	return result, nil
+}
+
+// PaneInfo describes the first pane of a tmux session.
+type PaneInfo struct {
+	Session      string
+	Path         string // current working directory
+	Command      string // foreground command
+	StartCommand string
+}
+
+// ClaudeSessionID returns the session ID the pane was started with, or ""
+// if it was not started by NewSession.
+func (p PaneInfo) ClaudeSessionID() string {
+	if m := startRe.FindStringSubmatch(p.StartCommand); m != nil {
+		return m[1]
+	}
+	if m := resumeRe.FindStringSubmatch(p.StartCommand); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ListPanes returns the first pane of every running tmux session.
+func ListPanes() ([]PaneInfo, error) {
+	format := "#{session_name}\t#{pane_current_path}\t#{pane_current_command}\t#{pane_start_command}"
+	out, err := exec.Command("tmux", "list-panes", "-a", "-F", format).Output()
+	if err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) {
+			msg := strings.TrimSpace(string(ee.Stderr))
+			if strings.Contains(msg, "no server running") || strings.Contains(msg, "error connecting") {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("list-panes failed: %s: %w", msg, err)
+		}
+		return nil, fmt.Errorf("list-panes failed: %w", err)
+	}
+	seen := make(map[string]bool)
+	var result []PaneInfo
+	for _, l := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		f := strings.SplitN(l, "\t", 4)
+		if len(f) < 4 || seen[f[0]] {
+			continue
+		}
+		seen[f[0]] = true
+		// tmux wraps the start command in double quotes.
+		start := f[3]
+		if len(start) >= 2 && start[0] == '"' && start[len(start)-1] == '"' {
+			start = start[1 : len(start)-1]
+		}
+		result = append(result, PaneInfo{Session: f[0], Path: f[1], Command: f[2], StartCommand: start})
+	}
+	return result, nil
+}
+
+// RenameSession renames a tmux session.
+func RenameSession(oldName, newName string) error {
+	cmd := exec.Command("tmux", "rename-session", "-t", oldName, newName)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tmux rename-session failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
 // CapturePane captures the visible content of a tmux pane as plain text.
 func CapturePane(name string, lines int) (string, error) {
 	start := fmt.Sprintf("-%d", lines)
@@ -93,7 +259,7 @@ func CapturePane(name string, lines int) (string, error) {
 
 // SendText sends literal text to a tmux pane (uses -l flag, no key interpretation).
 func SendText(name, text string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", name, "-l", text)
+	cmd := exec.Command("tmux", "send-keys", "-t", name, "-l", "--", text)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("send-keys literal failed: %s: %w", strings.TrimSpace(string(out)), err)
@@ -110,3 +276,47 @@ func SendSpecial(name, keyName string) error {
 	}
 	return nil
 }
+
+// PasteText delivers text to a tmux pane in one go through a paste buffer.
+// The paste is bracketed when the application has requested it, so newlines
+// arrive as part of the text instead of as Enter presses.
+func PasteText(name, text string) error {
+	buf := "ccdeck_" + name
+	load := exec.Command("tmux", "load-buffer", "-b", buf, "-")
+	load.Stdin = strings.NewReader(text)
+	if out, err := load.CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux load-buffer failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	paste := exec.Command("tmux", "paste-buffer", "-p", "-d", "-b", buf, "-t", name)
+	if out, err := paste.CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux paste-buffer failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// StopSession asks Claude to exit (Ctrl-C to clear the prompt, then /exit)
+// and waits up to timeout for the tmux session to end with it. If it is
+// still running after that, the session is killed. graceful reports whether
+// Claude exited on its own.
+func StopSession(name string, timeout time.Duration) (graceful bool, err error) {
+	if !SessionExists(name) {
+		return true, nil
+	}
+	// Errors are ignored here: the session may exit between any two steps,
+	// and the kill below is the fallback either way.
+	_ = SendSpecial(name, "C-c")
+	_ = SendText(name, "/exit")
+	_ = SendSpecial(name, "Enter")
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !SessionExists(name) {
+			return true, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	if !SessionExists(name) {
+		return true, nil
+	}
+	return false, KillSession(name)
+}
@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-session-manager/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// activeIdle is how recently a pane must have produced output for the
+	// time since the last refresh to count as active.
+	activeIdle = 10 * time.Second
+	// activeFlush is how much active time is accumulated in memory before
+	// it is written to the activity log.
+	activeFlush = time.Minute
+	// statsDays is the number of days charted in the stats view.
+	statsDays = 7
+)
+
+// recordActivity logs an event for the session with the given Session.ID.
+func (m *Model) recordActivity(sessionID string, kind model.EventKind) {
+	if sessionID == "" {
+		return
+	}
+	if err := m.activity.Record(model.Event{Session: sessionID, Kind: kind}); err != nil {
+		m.err = err
+	}
+}
+
+// recordActivityAt logs an event for the session at (gi, si).
+func (m *Model) recordActivityAt(gi, si int, kind model.EventKind) {
+	if ss := m.store.Sessions(gi); si >= 0 && si < len(ss) {
+		m.recordActivity(ss[si].ID, kind)
+	}
+}
+
+// sessionIDByTmux returns the Session.ID stored under a tmux name.
+func (m Model) sessionIDByTmux(tn string) string {
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			if m.tmuxNameAt(treePos{gi, si}) == tn {
+				return s.ID
+			}
+		}
+	}
+	return ""
+}
+
+// trackActive adds the time since the previous refresh to every session
+// whose pane produced output within activeIdle, and logs it once a session
+// has accumulated activeFlush.
+func (m *Model) trackActive(output map[string]time.Time, now time.Time) {
+	elapsed := now.Sub(m.lastRefresh)
+	m.lastRefresh = now
+	// The first refresh, or one after the TUI was suspended, has no
+	// meaningful interval.
+	if elapsed <= 0 || elapsed > activeIdle {
+		return
+	}
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			last, ok := output[m.tmuxNameAt(treePos{gi, si})]
+			if !ok || now.Sub(last) > activeIdle {
+				continue
+			}
+			m.activeAcc[s.ID] += elapsed
+			if d := m.activeAcc[s.ID]; d >= activeFlush {
+				m.recordActive(s.ID, d)
+			}
+		}
+	}
+}
+
+// recordActive logs the whole seconds of d and keeps the remainder.
+func (m *Model) recordActive(sessionID string, d time.Duration) {
+	secs := int64(d / time.Second)
+	if secs == 0 {
+		return
+	}
+	m.activeAcc[sessionID] = d - time.Duration(secs)*time.Second
+	if err := m.activity.Record(model.Event{Session: sessionID, Kind: model.EventActive, Seconds: secs}); err != nil {
+		m.err = err
+	}
+}
+
+// flushActive logs all accumulated active time; it is called on quit.
+func (m *Model) flushActive() {
+	for id, d := range m.activeAcc {
+		m.recordActive(id, d)
+	}
+}
+
+// activityFor returns the logged activity of a session plus active time not
+// yet written to the log.
+func (m Model) activityFor(sessionID string) model.Activity {
+	a := m.activity.For(sessionID)
+	a.Active += m.activeAcc[sessionID]
+	return a
+}
+
+// formatDuration renders an active time as "45s", "12m" or "3h 05m".
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// renderActivityLine formats last use, creation and active time for the
+// metadata header.
+func (m Model) renderActivityLine(sess model.Session) string {
+	a := m.activityFor(sess.ID)
+	used := "never used"
+	if t := a.LastUsed(); !t.IsZero() {
+		used = "used " + timeAgo(t)
+	}
+	line := metaValueStyle.Render(used) + dimStyle.Render(" · created "+timeAgo(sess.CreatedAt))
+	if a.Active > 0 {
+		line += dimStyle.Render(" · active " + formatDuration(a.Active))
+	}
+	return line
+}
+
+// openStats shows activity statistics for the selected group.
+func (m Model) openStats() (tea.Model, tea.Cmd) {
+	if len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	m.statsGroup = m.groupIdx
+	m.dialog = dialogStats
+	return m, nil
+}
+
+func (m Model) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.store.Groups())
+	switch {
+	case key.Matches(msg, keys.Left):
+		m.statsGroup = (m.statsGroup - 1 + n) % n
+	case key.Matches(msg, keys.Right):
+		m.statsGroup = (m.statsGroup + 1) % n
+	case key.Matches(msg, keys.Escape, keys.Stats, keys.Quit):
+		m.dialog = dialogNone
+	}
+	return m, nil
+}
+
+func (m Model) renderStatsDialog() string {
+	groups := m.store.Groups()
+	if m.statsGroup >= len(groups) {
+		return ""
+	}
+	title := dialogTitleStyle.Render("📊 Activity: " + m.store.GroupPath(m.statsGroup))
+
+	now := time.Now()
+	weekAgo := now.AddDate(0, 0, -statsDays)
+	ids := make(map[string]bool)
+	var total, week time.Duration
+	rows := []string{metaLabelStyle.Render(fmt.Sprintf("  %-16s %-12s %7s %7s %6s %6s %6s",
+		"session", "last used", "active", "7 days", "launch", "attach", "input"))}
+	for _, pos := range m.subtreeSessions(m.statsGroup) {
+		s := groups[pos.groupIdx].Sessions[pos.sessionIdx]
+		ids[s.ID] = true
+		a := m.activityFor(s.ID)
+		w := m.activity.ActiveSince(s.ID, weekAgo) + m.activeAcc[s.ID]
+		total += a.Active
+		week += w
+		used := "never"
+		if t := a.LastUsed(); !t.IsZero() {
+			used = timeAgo(t)
+		}
+		rows = append(rows, metaValueStyle.Render(fmt.Sprintf("  %-16s %-12s %7s %7s %6d %6d %6d",
+			truncate(s.Name, 16), used, formatDuration(a.Active), formatDuration(w), a.Launches, a.Attaches, a.Interactions)))
+	}
+	if len(ids) == 0 {
+		rows = append(rows, dimStyle.Render("  No sessions in this group."))
+	}
+	summary := metaLabelStyle.Render("Active ") + metaValueStyle.Render(formatDuration(total)) +
+		metaLabelStyle.Render("  last 7 days ") + metaValueStyle.Render(formatDuration(week))
+
+	chart := renderActiveChart(m.activity.ActivePerDay(ids, statsDays, now), now)
+	hint := dimStyle.Render("←→ group  esc close")
+	return dialogStyle.Width(76).Render(title + "\n\n" + strings.Join(rows, "\n") + "\n\n" + summary + "\n\n" + chart + "\n\n" + hint)
+}
+
+// renderActiveChart draws one bar per day, scaled to the busiest day.
+func renderActiveChart(days []time.Duration, now time.Time) string {
+	const barWidth = 30
+	var peak time.Duration
+	for _, d := range days {
+		peak = max(peak, d)
+	}
+	lines := []string{metaLabelStyle.Render("Active per day")}
+	for i, d := range days {
+		day := now.AddDate(0, 0, i-len(days)+1).Format("Mon 01-02")
+		bar := ""
+		if peak > 0 {
+			bar = strings.Repeat("█", int(float64(barWidth)*float64(d)/float64(peak)+0.5))
+		}
+		lines = append(lines, dimStyle.Render("  "+day+" ")+statusRunning.Render(bar)+dimStyle.Render(" "+formatDuration(d)))
+	}
+	return strings.Join(lines, "\n")
+}
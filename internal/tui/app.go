@@ -2,7 +2,7 @@ package tui
 
 import (
 	"fmt"
-	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -10,7 +10,9 @@ import (
 	"claude-session-manager/internal/tmux"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -34,16 +36,39 @@ const (
 	dialogNewSession
 	dialogDeleteConfirm
 	dialogRename
+	dialogTags
+	dialogBroadcast
+	dialogBroadcastConfirm
+	dialogBroadcastResult
+	dialogComposer
+	dialogSnippets
+	dialogSnippetParams
+	dialogStopGroupConfirm
+	dialogLaunchResult
+	dialogOrphans
+	dialogAdoptOrphan
+	dialogStats
+	dialogSearch
+	dialogSaveTemplate
+	dialogTemplates
+	dialogClone
+	dialogLaunchSettings
 )
 
 type tmuxExitMsg struct{ err error }
 
 type refreshMsg struct {
 	sessions map[string]bool
+	output   map[string]time.Time // last pane output by tmux name
+	at       time.Time
 	content  string
+	tiles    map[string]string // dashboard tile content by tmux name
 }
 
-type sendDoneMsg struct{ err error }
+type inputFlushedMsg struct {
+	target string
+	res    tmux.FlushResult
+}
 
 // treePos represents a position in the tree: group header or session
 type treePos struct {
@@ -69,6 +94,7 @@ var tmuxSpecialKeys = map[string]string{
 // Model is the main TUI model.
 type Model struct {
 	store *model.Store
+	cfg   *model.Config
 
 	// Panel focus
 	focus focusPanel
@@ -77,6 +103,8 @@ type Model struct {
 	groupIdx   int
 	sessionIdx int // -1 = cursor on group header, >=0 = cursor on session
 	expanded   map[int]bool
+	// newGroupParent is the group a new group is created in, or -1.
+	newGroupParent int
 
 	// Dialog
 	dialog       dialogMode
@@ -84,30 +112,168 @@ type Model struct {
 	inputIdx     int
 	deleteTarget string
 
+	// Help overlay
+	showHelp bool
+	helpView viewport.Model
+
 	// Interact mode
 	interactMode   bool
 	previewContent string
+	previewScroll  int // lines scrolled up from the bottom of the preview
+
+	// Mouse
+	leftWidth  int // tree panel width set by dragging the divider; 0 = auto
+	dragging   bool
+	lastClick  time.Time
+	lastClickY int
+
+	// Dashboard
+	dashboard   bool
+	dashSource  dashSource
+	dashGroup   int
+	dashIdx     int
+	dashContent map[string]string
+
+	// LIVE mode input queue, shared by all copies of the model
+	inputQueue   *tmux.InputQueue
+	inputBacklog bool
+
+	// Prompt composer
+	composer       textarea.Model
+	composerDraft  string
+	composerTarget string
+	historyIdx     int // -1 = editing the draft, >=0 = browsing history
+	history        *model.PromptHistory
+
+	// Snippets
+	snippets      []model.Snippet
+	snippetIdx    int
+	snippetTarget string
+	snippetChosen model.Snippet
+
+	// Broadcast
+	marked        map[string]bool // Session.ID of multi-selected sessions
+	lastBroadcast *model.Broadcast
+
+	// Group launch
+	launch       *launchState // in progress, nil when idle
+	launchReport *launchState // last finished launch with failures
+
+	// Diff viewer, nil when closed
+	diff *diffState
+
+	// Git status cache, refreshed in the background
+	gitCache   map[string]gitEntry
+	gitPending map[string]bool
+	// Repository root by directory ("" outside git), for the path field
+	rootCache   map[string]string
+	rootPending map[string]bool
+
+	// Activity log and active time not yet logged, by Session.ID
+	activity    *model.ActivityLog
+	activeAcc   map[string]time.Duration
+	lastRefresh time.Time
+	statsGroup  int
+
+	// Tree order and view, persisted in state.json
+	uiState    *model.UIState
+	sortMode   sortMode
+	flat       bool                 // all sessions without group headers
+	paneOutput map[string]time.Time // last pane output by tmux name
+	seen       map[string]time.Time // last time a session was previewed, by Session.ID
+	// showArchived lists archived sessions in a section below the tree.
+	showArchived bool
+	// inPinned is set when the cursor is on a session's entry in the Pinned
+	// section rather than in its group.
+	inPinned bool
+
+	// Session search
+	searchIdx int
+
+	// Templates and cloning
+	templateIdx int
+	newTemplate *model.Template // applied to the session being created
+	cloneFork   bool            // clone forks the conversation
+
+	// Token usage cache, by Claude session ID
+	usageCache   map[string]usageEntry
+	usagePending map[string]bool
+
+	// Session ID capture of new conversations
+	capturing     bool
+	captureAt     time.Time       // last lookup
+	captureShared map[string]bool // by Session.ID, sharing a folder with another
+
+	// New-session path field
+	pathCheck       pathCheck
+	pathCompletions []string
+
+	// Orphan reconciliation
+	orphans     []tmux.PaneInfo
+	orphanIdx   int
+	orphanKill  bool   // waiting for y/n to kill the selected orphan
+	orphanAdopt string // tmux name being adopted
 
 	width  int
 	height int
 
 	statusMsg    string
 	err          error
+	quitErr      error // from saving state on quit, see QuitErr
 	tmuxSessions map[string]bool
 }
 
 // New creates a new TUI Model.
-func New(store *model.Store) Model {
+func New(store *model.Store, cfg *model.Config) Model {
 	exp := make(map[int]bool)
 	for i := range store.Groups() {
 		exp[i] = true
 	}
-	return Model{
+	// A broken history file must not keep the TUI from starting; the error
+	// is shown in the status bar and the history starts empty.
+	history, err := model.LoadPromptHistory()
+	if history == nil {
+		history = &model.PromptHistory{}
+	}
+	activity, aerr := model.LoadActivityLog()
+	if activity == nil {
+		activity = &model.ActivityLog{}
+	}
+	if err == nil {
+		err = aerr
+	}
+	uiState, serr := model.LoadUIState()
+	if uiState == nil {
+		uiState = &model.UIState{}
+	}
+	if err == nil {
+		err = serr
+	}
+	m := Model{
+		err:          err,
+		cfg:          cfg,
+		history:      history,
+		activity:     activity,
+		activeAcc:    make(map[string]time.Duration),
+		uiState:      uiState,
+		sortMode:     parseSortMode(uiState.Sort),
+		flat:         uiState.View == "flat",
+		paneOutput:   make(map[string]time.Time),
+		seen:         make(map[string]time.Time),
 		store:        store,
 		sessionIdx:   -1,
 		expanded:     exp,
+		marked:       make(map[string]bool),
 		tmuxSessions: make(map[string]bool),
-	}
+		gitCache:     make(map[string]gitEntry),
+		gitPending:   make(map[string]bool),
+		rootCache:    make(map[string]string),
+		rootPending:  make(map[string]bool),
+		usageCache:   make(map[string]usageEntry),
+		usagePending: make(map[string]bool),
+	}
+	m.restoreUIState()
+	return m
 }
 
 // onGroupHeader returns true if cursor is on a group header (not a session).
@@ -130,9 +296,9 @@ func (m Model) scheduleRefresh() tea.Cmd {
 }
 
 func (m Model) doRefresh() tea.Msg {
-	sessions, _ := tmux.ListSessions()
+	output, _ := tmux.ListActivity()
 	result := make(map[string]bool)
-	for _, s := range sessions {
+	for s := range output {
 		result[s] = true
 	}
 	content := ""
@@ -143,7 +309,20 @@ func (m Model) doRefresh() tea.Msg {
 			content = captured
 		}
 	}
-	return refreshMsg{sessions: result, content: content}
+	var tiles map[string]string
+	if m.dashboard {
+		tiles = make(map[string]string)
+		for _, p := range m.dashTiles() {
+			tn := m.tmuxNameAt(p)
+			if !result[tn] {
+				continue
+			}
+			if captured, err := tmux.CapturePane(tn, dashCaptureLines); err == nil {
+				tiles[tn] = captured
+			}
+		}
+	}
+	return refreshMsg{sessions: result, output: output, at: time.Now(), content: content, tiles: tiles}
 }
 
 func (m Model) selectedTmuxName() string {
@@ -155,7 +334,32 @@ func (m Model) selectedTmuxName() string {
 	if m.sessionIdx >= len(ss) {
 		return ""
 	}
-	return tmux.SanitizeName(groups[m.groupIdx].Name, ss[m.sessionIdx].Name)
+	return m.tmuxNameAt(treePos{m.groupIdx, m.sessionIdx})
+}
+
+// tmuxNameTaken reports whether a session called name in group gi would get
+// the tmux name of a stored session. Sessions sharing a tmux name would
+// attach to each other's Claude.
+func (m Model) tmuxNameTaken(gi int, name string) bool {
+	tn := tmux.SanitizeName(m.store.GroupPath(gi), name)
+	for g, grp := range m.store.Groups() {
+		for si := range grp.Sessions {
+			if m.tmuxNameAt(treePos{g, si}) == tn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// uniqueSessionName returns name, or name with " 2", " 3"... appended, the
+// first whose tmux name is free in group gi.
+func (m Model) uniqueSessionName(gi int, name string) string {
+	unique := name
+	for n := 2; m.tmuxNameTaken(gi, unique); n++ {
+		unique = fmt.Sprintf("%s %d", name, n)
+	}
+	return unique
 }
 
 // ---------------------------------------------------------------------------
@@ -163,20 +367,45 @@ func (m Model) selectedTmuxName() string {
 // ---------------------------------------------------------------------------
 
 func (m Model) buildTree() []treePos {
-	var tree []treePos
-	for gi, g := range m.store.Groups() {
+	tree := m.pinnedOrder()
+	if m.flat {
+		tree = append(tree, m.flatOrder()...)
+	} else {
+		tree = m.appendGroupTree(tree, "")
+	}
+	if m.showArchived {
+		tree = append(tree, m.sortedSessions(true)...)
+	}
+	return tree
+}
+
+// appendGroupTree appends the groups inside parentID in display order, each
+// followed, when expanded, by its sessions and then its subgroups.
+func (m Model) appendGroupTree(tree []treePos, parentID string) []treePos {
+	groups := m.store.Groups()
+	for _, gi := range m.groupOrder(parentID) {
 		tree = append(tree, treePos{gi, -1})
 		if m.expanded[gi] {
-			for si := range g.Sessions {
+			for _, si := range m.sessionOrder(gi) {
 				tree = append(tree, treePos{gi, si})
 			}
+			tree = m.appendGroupTree(tree, groups[gi].ID)
 		}
 	}
 	return tree
 }
 
+// currentTreeIdx returns the tree index of the cursor. A pinned session is
+// listed twice, in the Pinned section and in its group; inPinned tells
+// which of the two the cursor is on.
 func (m Model) currentTreeIdx() int {
 	tree := m.buildTree()
+	n := len(m.pinnedOrder())
+	for i, p := range tree {
+		if p.groupIdx == m.groupIdx && p.sessionIdx == m.sessionIdx && (i < n) == m.cursorInPinned() {
+			return i
+		}
+	}
 	for i, p := range tree {
 		if p.groupIdx == m.groupIdx && p.sessionIdx == m.sessionIdx {
 			return i
@@ -185,6 +414,13 @@ func (m Model) currentTreeIdx() int {
 	return 0
 }
 
+// selectTreeIdx moves the cursor to tree index i.
+func (m *Model) selectTreeIdx(tree []treePos, i int) {
+	m.groupIdx = tree[i].groupIdx
+	m.sessionIdx = tree[i].sessionIdx
+	m.inPinned = i < len(m.pinnedOrder())
+}
+
 func (m *Model) moveTree(delta int) {
 	tree := m.buildTree()
 	if len(tree) == 0 {
@@ -198,20 +434,57 @@ func (m *Model) moveTree(delta int) {
 	if next >= len(tree) {
 		next = len(tree) - 1
 	}
-	m.groupIdx = tree[next].groupIdx
-	m.sessionIdx = tree[next].sessionIdx
+	m.selectTreeIdx(tree, next)
+	m.previewScroll = 0
 }
 
+// activeCountForGroup counts the running sessions of group gi and of the
+// groups nested in it.
 func (m Model) activeCountForGroup(gi int) int {
-	groups := m.store.Groups()
-	if gi >= len(groups) {
-		return 0
+	count := 0
+	for _, g := range m.store.Subtree(gi) {
+		for si := range m.store.Sessions(g) {
+			if m.tmuxSessions[m.tmuxNameAt(treePos{g, si})] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// sessionCountForGroup counts the sessions of group gi and of the groups
+// nested in it, leaving out archived ones.
+func (m Model) sessionCountForGroup(gi int) int {
+	count := 0
+	for _, g := range m.store.Subtree(gi) {
+		count += len(m.sessionOrder(g))
+	}
+	return count
+}
+
+// archivedCountForGroup counts the archived sessions of group gi and of the
+// groups nested in it.
+func (m Model) archivedCountForGroup(gi int) int {
+	count := 0
+	for _, g := range m.store.Subtree(gi) {
+		for _, s := range m.store.Sessions(g) {
+			if s.Archived {
+				count++
+			}
+		}
 	}
+	return count
+}
+
+// worktreeCountForGroup counts the worktree sessions of group gi and of the
+// groups nested in it, archived ones included.
+func (m Model) worktreeCountForGroup(gi int) int {
 	count := 0
-	for _, s := range groups[gi].Sessions {
-		tn := tmux.SanitizeName(groups[gi].Name, s.Name)
-		if m.tmuxSessions[tn] {
-			count++
+	for _, g := range m.store.Subtree(gi) {
+		for _, s := range m.store.Sessions(g) {
+			if s.WorktreeOf != "" {
+				count++
+			}
 		}
 	}
 	return count
@@ -226,18 +499,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if m.showHelp {
+			m.resizeHelp()
+		}
+		if m.dialog == dialogComposer {
+			m.resizeComposer()
+		}
 		return m, nil
 
 	case refreshMsg:
 		m.tmuxSessions = msg.sessions
 		m.previewContent = msg.content
-		return m, m.scheduleRefresh()
+		m.dashContent = msg.tiles
+		m.trackActive(msg.output, msg.at)
+		m.paneOutput = msg.output
+		m.markSeen()
+		return m, tea.Batch(m.scheduleRefresh(), m.refreshGitStatus(), m.refreshUsage(), m.refreshCapture())
 
-	case sendDoneMsg:
-		if msg.err != nil {
-			m.statusMsg = fmt.Sprintf("Send failed: %v", msg.err)
-		}
-		return m, nil
+	case gitStatusMsg:
+		return m.handleGitStatus(msg)
+
+	case gitRootMsg:
+		return m.handleGitRoot(msg)
+
+	case capturedMsg:
+		return m.handleCaptured(msg)
+
+	case usageMsg:
+		return m.handleUsage(msg)
+
+	case diffLoadedMsg:
+		return m.handleDiffLoaded(msg)
+
+	case hunkAppliedMsg:
+		return m.handleHunkApplied(msg)
+
+	case worktreeCreatedMsg:
+		return m.handleWorktreeCreated(msg)
+
+	case worktreeRemovedMsg:
+		return m.handleWorktreeRemoved(msg)
+
+	case inputFlushedMsg:
+		return m.handleInputFlushed(msg)
+
+	case composerSentMsg:
+		return m.handleComposerSent(msg)
+
+	case snippetBranchMsg:
+		return m.handleSnippetBranch(msg)
+
+	case lifecycleDoneMsg:
+		return m.handleLifecycleDone(msg)
+
+	case launchProgressMsg:
+		return m.handleLaunchProgress(msg)
+
+	case broadcastDoneMsg:
+		return m.handleBroadcastDone(msg)
 
 	case tmuxExitMsg:
 		m.statusMsg = "Returned from tmux session"
@@ -246,18 +565,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case tea.KeyMsg:
-		if m.dialog != dialogNone {
-			return m.updateDialog(msg)
-		}
-		if m.interactMode {
-			return m.updateInteract(msg)
+	case tea.MouseMsg:
+		if m.dialog != dialogNone || m.showHelp || m.dashboard || m.diff != nil {
+			return m, nil
 		}
-		return m.updateNormal(msg)
+		next, cmd := m.updateMouse(msg)
+		return next.(Model).saveLayout(), cmd
+
+	case tea.KeyMsg:
+		next, cmd := m.updateKey(msg)
+		return next.(Model).saveLayout(), cmd
 	}
 	return m, nil
 }
 
+func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.dialog != dialogNone {
+		return m.updateDialog(msg)
+	}
+	if m.showHelp {
+		return m.updateHelp(msg)
+	}
+	if m.interactMode {
+		return m.updateInteract(msg)
+	}
+	if m.diff != nil {
+		return m.updateDiff(msg)
+	}
+	if m.dashboard {
+		return m.updateDashboard(msg)
+	}
+	return m.updateNormal(msg)
+}
+
 // ---------------------------------------------------------------------------
 // Normal mode
 // ---------------------------------------------------------------------------
@@ -265,8 +605,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, keys.Quit):
+		m.shutdown()
 		return m, tea.Quit
 
+	case key.Matches(msg, keys.Help):
+		return m.openHelp(), nil
+
 	case key.Matches(msg, keys.Tab):
 		if m.focus == panelTree {
 			m.focus = panelPreview
@@ -315,36 +659,151 @@ func (m Model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.focus = panelPreview
 		m.interactMode = true
+		m.previewScroll = 0
+		m.recordActivityAt(m.groupIdx, m.sessionIdx, model.EventInteract)
 		m.statusMsg = ""
 		return m, nil
 
-	case key.Matches(msg, keys.NewGrp):
-		m.dialog = dialogNewGroup
-		m.inputs = []textinput.Model{newInput("Group name", "e.g. Work", 30)}
+	case key.Matches(msg, keys.Dashboard):
+		if len(m.store.Groups()) == 0 {
+			return m, nil
+		}
+		return m.openDashboard(), nil
+
+	case key.Matches(msg, keys.Pin):
+		if m.onGroupHeader() {
+			return m, nil
+		}
+		return m.togglePin()
+
+	case key.Matches(msg, keys.Mark):
+		if m.focus != panelTree {
+			return m, nil
+		}
+		return m.toggleMark()
+
+	case key.Matches(msg, keys.Escape):
+		if len(m.marked) > 0 {
+			m.marked = make(map[string]bool)
+			m.statusMsg = "Cleared marks"
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Broadcast):
+		return m.openBroadcast()
+
+	case key.Matches(msg, keys.Stop):
+		return m.stopSelected()
+
+	case key.Matches(msg, keys.Restart):
+		return m.restartSelected()
+
+	case key.Matches(msg, keys.Launch):
+		return m.launchGroup()
+
+	case key.Matches(msg, keys.Archive):
+		return m.toggleArchive()
+
+	case key.Matches(msg, keys.Jump):
+		return m.quickSwitch(int(msg.Runes[0] - '0'))
+
+	case key.Matches(msg, keys.Archived):
+		return m.toggleArchived()
+
+	case key.Matches(msg, keys.Search):
+		return m.openSearch()
+
+	case key.Matches(msg, keys.Orphans):
+		return m.openOrphans()
+
+	case key.Matches(msg, keys.Diff):
+		return m.openDiff()
+
+	case key.Matches(msg, keys.Stats):
+		return m.openStats()
+
+	case key.Matches(msg, keys.Sort):
+		return m.cycleSort()
+
+	case key.Matches(msg, keys.Flat):
+		return m.toggleFlat()
+
+	case key.Matches(msg, keys.Compose):
+		if m.onGroupHeader() {
+			return m, nil
+		}
+		return m.openComposer()
+
+	case key.Matches(msg, keys.Snippets):
+		if m.onGroupHeader() {
+			return m, nil
+		}
+		return m.openSnippets()
+
+	case key.Matches(msg, keys.Tags):
+		sessions := m.store.Sessions(m.groupIdx)
+		if m.sessionIdx < 0 || m.sessionIdx >= len(sessions) {
+			return m, nil
+		}
+		m.dialog = dialogTags
+		m.inputs = []textinput.Model{newInput("Tags", "comma separated, e.g. backend, tests", 40)}
+		m.inputs[0].SetValue(strings.Join(sessions[m.sessionIdx].Tags, ", "))
 		m.inputIdx = 0
 		m.inputs[0].Focus()
 		return m, textinput.Blink
 
+	case key.Matches(msg, keys.NewGrp):
+		return m.openNewGroup(-1)
+
+	case key.Matches(msg, keys.SubGrp):
+		if len(m.store.Groups()) == 0 {
+			m.statusMsg = "Create a group first (press g)"
+			return m, nil
+		}
+		return m.openNewGroup(m.groupIdx)
+
 	case key.Matches(msg, keys.NewSess):
 		if len(m.store.Groups()) == 0 {
 			m.statusMsg = "Create a group first (press g)"
 			return m, nil
 		}
 		m.dialog = dialogNewSession
+		m.newTemplate = nil
+		m.pathCheck = pathCheck{}
+		m.pathCompletions = nil
 		m.inputs = []textinput.Model{
 			newInput("Project path", "~/projects/my-app", 60),
-			newInput("Session ID / Name", "session id or rename", 60),
+			newInput("Session ID / Name", "empty to start a new conversation", 60),
 			newInput("Display name (optional)", "e.g. api-refactor", 30),
+			newInput("Worktree branch (optional)", "new branch for a git worktree", 40),
 		}
 		m.inputIdx = 0
 		m.inputs[0].Focus()
 		return m, textinput.Blink
 
+	case key.Matches(msg, keys.FromTmpl):
+		return m.openTemplates()
+
+	case key.Matches(msg, keys.Template):
+		return m.openSaveTemplate()
+
+	case key.Matches(msg, keys.Clone):
+		return m.openClone()
+
+	case key.Matches(msg, keys.LaunchCfg):
+		return m.openLaunchSettings()
+
 	case key.Matches(msg, keys.Delete):
 		if m.focus != panelTree || len(m.store.Groups()) == 0 {
 			return m, nil
 		}
 		if m.onGroupHeader() {
+			// Deleting the group would forget worktrees without removing
+			// them; they go one by one through "w" in the session delete.
+			if n := m.worktreeCountForGroup(m.groupIdx); n > 0 {
+				m.statusMsg = fmt.Sprintf("%s has %d worktree sessions; delete them first (d, then w)", m.store.GroupPath(m.groupIdx), n)
+				return m, nil
+			}
 			m.dialog = dialogDeleteConfirm
 			m.deleteTarget = "group"
 		} else {
@@ -387,52 +846,87 @@ func (m Model) updateInteract(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if tn == "" || !m.tmuxSessions[tn] {
 		m.interactMode = false
 		m.statusMsg = "Session ended"
-		return m, nil
+		return m, m.closeInputQueue()
 	}
 
 	keyStr := msg.String()
 
-	if keyStr == "ctrl+q" {
+	if key.Matches(msg, keys.ExitLive) {
 		m.interactMode = false
 		m.statusMsg = "Exited interact mode"
-		return m, nil
+		return m, m.closeInputQueue()
 	}
 
+	if msg.Paste {
+		// Deliver pastes in one bracketed paste so newlines don't submit.
+		return m.enqueueKey(tn, tmux.Key{Kind: tmux.KeyPaste, Text: string(msg.Runes)})
+	}
 	if tmuxKey, ok := tmuxSpecialKeys[keyStr]; ok {
-		return m, m.sendSpecialCmd(tn, tmuxKey)
+		return m.enqueueKey(tn, tmux.Key{Kind: tmux.KeySpecial, Text: tmuxKey})
 	}
 	if strings.HasPrefix(keyStr, "ctrl+") {
-		return m, m.sendSpecialCmd(tn, "C-"+strings.TrimPrefix(keyStr, "ctrl+"))
+		return m.enqueueKey(tn, tmux.Key{Kind: tmux.KeySpecial, Text: "C-" + strings.TrimPrefix(keyStr, "ctrl+")})
 	}
 	if strings.HasPrefix(keyStr, "alt+") {
-		return m, m.sendSpecialCmd(tn, "M-"+strings.TrimPrefix(keyStr, "alt+"))
+		return m.enqueueKey(tn, tmux.Key{Kind: tmux.KeySpecial, Text: "M-" + strings.TrimPrefix(keyStr, "alt+")})
 	}
 	if msg.Type == tea.KeyRunes {
-		return m, m.sendTextCmd(tn, string(msg.Runes))
+		return m.enqueueKey(tn, tmux.Key{Kind: tmux.KeyLiteral, Text: string(msg.Runes)})
 	}
 	if msg.Type == tea.KeySpace {
-		return m, m.sendTextCmd(tn, " ")
+		return m.enqueueKey(tn, tmux.Key{Kind: tmux.KeyLiteral, Text: " "})
 	}
 	return m, nil
 }
 
-func (m Model) sendTextCmd(tn, text string) tea.Cmd {
-	return func() tea.Msg {
-		return sendDoneMsg{err: tmux.SendText(tn, text)}
-	}
-}
-
-func (m Model) sendSpecialCmd(tn, keyName string) tea.Cmd {
-	return func() tea.Msg {
-		return sendDoneMsg{err: tmux.SendSpecial(tn, keyName)}
-	}
-}
-
 // ---------------------------------------------------------------------------
 // Dialog handling
 // ---------------------------------------------------------------------------
 
 func (m Model) updateDialog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.dialog {
+	case dialogComposer:
+		return m.updateComposer(msg)
+	case dialogSnippets:
+		return m.updateSnippets(msg)
+	case dialogBroadcastConfirm:
+		return m.updateBroadcastConfirm(msg)
+	case dialogOrphans:
+		return m.updateOrphans(msg)
+	case dialogStats:
+		return m.updateStats(msg)
+	case dialogSearch:
+		return m.updateSearch(msg)
+	case dialogTemplates:
+		return m.updateTemplates(msg)
+	case dialogClone:
+		return m.updateClone(msg)
+	case dialogStopGroupConfirm:
+		if key.Matches(msg, keys.Yes) {
+			m.dialog = dialogNone
+			return m.startLifecycle(actionStop, m.runningJobsInGroup(m.groupIdx))
+		}
+		if key.Matches(msg, keys.No, keys.Escape) {
+			m.dialog = dialogNone
+		}
+		return m, nil
+	case dialogBroadcastResult, dialogLaunchResult:
+		if msg.Type == tea.KeyEnter || key.Matches(msg, keys.Escape) {
+			m.dialog = dialogNone
+		}
+		return m, nil
+	}
+
+	if m.dialog == dialogNewSession && m.inputIdx == 0 {
+		switch {
+		case key.Matches(msg, keys.NextField) && m.completePath():
+			return m, m.lookupGitRoot()
+		case key.Matches(msg, keys.UseRoot):
+			m.useGitRoot()
+			return m, m.lookupGitRoot()
+		}
+	}
+
 	switch {
 	case key.Matches(msg, keys.Escape):
 		m.dialog = dialogNone
@@ -460,7 +954,13 @@ func (m Model) updateDialog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	if m.dialog == dialogDeleteConfirm {
 		if key.Matches(msg, keys.Yes) {
-			return m.confirmDelete()
+			return m.confirmDelete(m.cfg.StopOnDelete)
+		}
+		if key.Matches(msg, keys.StopToo) {
+			return m.confirmDelete(true)
+		}
+		if key.Matches(msg, keys.Worktree) && m.deleteTarget == "session" {
+			return m.deleteWithWorktree()
 		}
 		if msg.String() == "n" || key.Matches(msg, keys.Escape) {
 			m.dialog = dialogNone
@@ -473,44 +973,99 @@ func (m Model) updateDialog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.inputIdx < len(m.inputs) {
 		m.inputs[m.inputIdx], cmd = m.inputs[m.inputIdx].Update(msg)
 	}
-	return m, cmd
+	m.updatePathCheck()
+	return m, tea.Batch(cmd, m.lookupGitRoot())
 }
 
 func (m Model) submitDialog() (tea.Model, tea.Cmd) {
 	switch m.dialog {
 	case dialogNewGroup:
-		name := strings.TrimSpace(m.inputs[0].Value())
-		if name == "" {
-			m.statusMsg = "Group name cannot be empty"
-			return m, nil
-		}
-		idx := m.store.AddGroup(name)
-		if err := m.store.Save(); err != nil {
-			m.err = err
-		}
-		m.groupIdx = idx
-		m.sessionIdx = -1
-		m.expanded[idx] = true
-		m.statusMsg = fmt.Sprintf("Created group: %s", name)
+		return m.submitNewGroup()
 
 	case dialogNewSession:
 		path := strings.TrimSpace(m.inputs[0].Value())
 		sessionID := strings.TrimSpace(m.inputs[1].Value())
 		displayName := strings.TrimSpace(m.inputs[2].Value())
-		if path == "" || sessionID == "" {
-			m.statusMsg = "Path and Session ID are required"
+		if path == "" {
+			m.statusMsg = "Path is required"
 			return m, nil
 		}
-		if displayName == "" {
-			displayName = sessionID
+		c := checkPath(path)
+		if !c.isDir {
+			m.statusMsg = fmt.Sprintf("Not a directory: %s", c.expanded)
+			return m, nil
+		}
+		switch {
+		case displayName != "":
+			if m.tmuxNameTaken(m.groupIdx, displayName) {
+				m.statusMsg = fmt.Sprintf("A session named %s already exists here", displayName)
+				return m, nil
+			}
+		case sessionID != "":
+			displayName = m.uniqueSessionName(m.groupIdx, sessionID)
+		case m.newTemplate != nil:
+			displayName = m.uniqueSessionName(m.groupIdx, m.newTemplate.Name)
+		default:
+			displayName = m.uniqueSessionName(m.groupIdx, filepath.Base(c.expanded))
+		}
+		if branch := strings.TrimSpace(m.inputs[3].Value()); branch != "" {
+			root, known := m.rootCache[c.dir()]
+			if known && root == "" {
+				m.statusMsg = "A worktree needs a path inside a git repository"
+				return m, nil
+			}
+			msg := worktreeCreatedMsg{
+				groupID:   m.store.Groups()[m.groupIdx].ID,
+				name:      displayName,
+				sessionID: sessionID,
+				repo:      root,
+				template:  m.newTemplate,
+			}
+			m.dialog = dialogNone
+			m.inputs = nil
+			m.statusMsg = fmt.Sprintf("Creating worktree for branch %s...", branch)
+			return m, createWorktreeCmd(msg, c.expanded, branch)
 		}
 		idx := m.store.AddSession(m.groupIdx, displayName, sessionID, path)
+		m.initSession(m.groupIdx, idx, m.newTemplate, false)
 		if err := m.store.Save(); err != nil {
 			m.err = err
 		}
 		m.expanded[m.groupIdx] = true
 		m.sessionIdx = idx
 		m.statusMsg = fmt.Sprintf("Created session: %s", displayName)
+		if sessionID == "" {
+			m.statusMsg += " (new conversation, its ID is captured after the first prompt)"
+		}
+
+	case dialogBroadcast:
+		return m.submitBroadcast()
+
+	case dialogSnippetParams:
+		return m.submitSnippetParams()
+
+	case dialogAdoptOrphan:
+		return m.submitAdopt()
+
+	case dialogSaveTemplate:
+		return m.submitSaveTemplate()
+
+	case dialogLaunchSettings:
+		return m.submitLaunchSettings()
+
+	case dialogTags:
+		var tags []string
+		for _, t := range strings.Split(m.inputs[0].Value(), ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+		s := &m.store.Data.Groups[m.groupIdx].Sessions[m.sessionIdx]
+		s.Tags = tags
+		if err := m.store.Save(); err != nil {
+			m.err = err
+		}
+		m.statusMsg = fmt.Sprintf("Tags for %s: %s", s.Name, strings.Join(tags, ", "))
 
 	case dialogRename:
 		name := strings.TrimSpace(m.inputs[0].Value())
@@ -518,6 +1073,10 @@ func (m Model) submitDialog() (tea.Model, tea.Cmd) {
 			m.statusMsg = "Name cannot be empty"
 			return m, nil
 		}
+		if m.deleteTarget == "group" && strings.Contains(name, "/") {
+			m.statusMsg = "Group names cannot contain /"
+			return m, nil
+		}
 		if m.deleteTarget == "group" {
 			m.store.Data.Groups[m.groupIdx].Name = name
 		} else {
@@ -534,10 +1093,41 @@ func (m Model) submitDialog() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m Model) confirmDelete() (tea.Model, tea.Cmd) {
+// deleteSessionAt removes the session at (gi, si) from the store, keeping
+// the cursor on a valid row. The caller saves.
+func (m *Model) deleteSessionAt(gi, si int) {
+	delete(m.marked, m.store.Sessions(gi)[si].ID)
+	m.store.DeleteSession(gi, si)
+	if gi != m.groupIdx || m.sessionIdx < si {
+		return
+	}
+	if m.sessionIdx > si {
+		m.sessionIdx--
+		return
+	}
+	remaining := len(m.store.Sessions(gi))
+	if remaining == 0 {
+		m.sessionIdx = -1
+	} else if m.sessionIdx >= remaining {
+		m.sessionIdx = remaining - 1
+	}
+}
+
+// confirmDelete removes the selected group or session. With stop set, the
+// affected tmux sessions are stopped gracefully in the background.
+func (m Model) confirmDelete(stop bool) (tea.Model, tea.Cmd) {
+	var jobs []lifecycleJob
 	if m.deleteTarget == "group" {
-		name := m.store.Groups()[m.groupIdx].Name
+		name := m.store.GroupPath(m.groupIdx)
+		jobs = m.runningJobsInGroup(m.groupIdx)
+		for _, gi := range m.store.Subtree(m.groupIdx) {
+			for _, s := range m.store.Sessions(gi) {
+				delete(m.marked, s.ID)
+			}
+		}
+		expanded := m.expandedIDs()
 		m.store.DeleteGroup(m.groupIdx)
+		m.setExpandedIDs(expanded)
 		if len(m.store.Groups()) == 0 {
 			m.groupIdx = 0
 			m.sessionIdx = -1
@@ -550,18 +1140,44 @@ func (m Model) confirmDelete() (tea.Model, tea.Cmd) {
 		sessions := m.store.Sessions(m.groupIdx)
 		if m.sessionIdx < len(sessions) {
 			name := sessions[m.sessionIdx].Name
-			m.store.DeleteSession(m.groupIdx, m.sessionIdx)
-			remaining := len(m.store.Sessions(m.groupIdx))
-			if remaining == 0 {
-				m.sessionIdx = -1
-			} else if m.sessionIdx >= remaining {
-				m.sessionIdx = remaining - 1
+			if j := m.jobAt(m.groupIdx, m.sessionIdx); m.tmuxSessions[j.tmuxName] {
+				jobs = append(jobs, j)
 			}
+			m.deleteSessionAt(m.groupIdx, m.sessionIdx)
 			m.statusMsg = fmt.Sprintf("Deleted session: %s", name)
 		}
 	}
 	_ = m.store.Save()
 	m.dialog = dialogNone
+	if stop && len(jobs) > 0 {
+		m.statusMsg += fmt.Sprintf(" • stopping %d tmux sessions...", len(jobs))
+		return m, m.lifecycleCmd(actionStop, jobs)
+	}
+	return m, nil
+}
+
+// togglePin pins or unpins the selected session.
+func (m Model) togglePin() (tea.Model, tea.Cmd) {
+	sessions := m.store.Sessions(m.groupIdx)
+	if m.sessionIdx < 0 || m.sessionIdx >= len(sessions) {
+		return m, nil
+	}
+	s := &m.store.Data.Groups[m.groupIdx].Sessions[m.sessionIdx]
+	s.Pinned = !s.Pinned
+	s.PinnedAt = time.Time{}
+	if s.Pinned {
+		s.PinnedAt = time.Now()
+	} else {
+		m.inPinned = false
+	}
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	if s.Pinned {
+		m.statusMsg = fmt.Sprintf("Pinned: %s", s.Name)
+	} else {
+		m.statusMsg = fmt.Sprintf("Unpinned: %s", s.Name)
+	}
 	return m, nil
 }
 
@@ -575,17 +1191,23 @@ func (m Model) attachSession() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	sess := sessions[m.sessionIdx]
-	group := m.store.Groups()[m.groupIdx]
-	tmuxName := tmux.SanitizeName(group.Name, sess.Name)
+	if sess.Archived {
+		m.statusMsg = "Session is archived. Press z to restore it."
+		return m, nil
+	}
+	tmuxName := m.tmuxNameAt(treePos{m.groupIdx, m.sessionIdx})
 
 	if !tmux.SessionExists(tmuxName) {
-		path := expandPath(sess.Path)
-		if err := tmux.NewSession(tmuxName, path, sess.SessionID); err != nil {
+		m.recordBaseline(m.groupIdx, m.sessionIdx)
+		path := model.ExpandPath(sess.Path)
+		if err := tmux.NewSession(tmuxName, path, launchFor(sess)); err != nil {
 			m.statusMsg = fmt.Sprintf("Error: %v", err)
 			m.err = err
 			return m, nil
 		}
+		m.recordActivity(sess.ID, model.EventLaunch)
 	}
+	m.recordActivity(sess.ID, model.EventAttach)
 
 	cmd := tmux.AttachCmd(tmuxName)
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
@@ -607,29 +1229,27 @@ func (m Model) View() string {
 	headerText := fmt.Sprintf(" %s  Claude Session Manager", logoIcon)
 	header := titleStyle.Width(m.width).Render(headerText)
 
-	contentHeight := m.height - 4
-	if contentHeight < 8 {
-		contentHeight = 8
-	}
+	leftWidth, rightWidth, contentHeight := m.layout()
 
-	leftWidth := m.width / 3
-	if leftWidth < 30 {
-		leftWidth = 30
-	}
-	if leftWidth > 50 {
-		leftWidth = 50
+	var content string
+	if m.diff != nil {
+		content = m.renderDiff(m.width, contentHeight+2)
+	} else if m.dashboard {
+		content = m.renderDashboard(m.width, contentHeight+2)
+	} else {
+		leftPanel := m.renderTreePanel(leftWidth, contentHeight)
+		rightPanel := m.renderPreviewPanel(rightWidth, contentHeight)
+		content = lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
 	}
-	rightWidth := m.width - leftWidth - 4
-
-	leftPanel := m.renderTreePanel(leftWidth, contentHeight)
-	rightPanel := m.renderPreviewPanel(rightWidth, contentHeight)
-
-	content := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
 
 	// ── Footer ────────────────────────────────────────────────────────────
 	var helpLine string
 	if m.interactMode {
 		helpLine = interactHelpStyle.Render(interactHelpText())
+	} else if m.diff != nil {
+		helpLine = helpStyle.Render(diffHelpText())
+	} else if m.dashboard {
+		helpLine = helpStyle.Render(dashboardHelpText())
 	} else {
 		helpLine = helpStyle.Render(normalHelpText())
 	}
@@ -649,10 +1269,35 @@ func (m Model) View() string {
 	if m.dialog != dialogNone {
 		overlay := m.renderDialog()
 		page = m.overlayDialog(page, overlay)
+	} else if m.showHelp {
+		page = m.overlayDialog(page, m.renderHelp())
 	}
 	return page
 }
 
+// layout returns the inner widths of the tree and preview panels and the
+// height shared by both.
+func (m Model) layout() (leftWidth, rightWidth, contentHeight int) {
+	contentHeight = m.height - 4
+	if contentHeight < 8 {
+		contentHeight = 8
+	}
+
+	leftWidth = m.leftWidth
+	if leftWidth == 0 {
+		leftWidth = m.width / 3
+		if leftWidth < 30 {
+			leftWidth = 30
+		}
+		if leftWidth > 50 {
+			leftWidth = 50
+		}
+	}
+	leftWidth = min(max(leftWidth, minTreeWidth), max(m.width-minPreviewWidth-4, minTreeWidth))
+	rightWidth = m.width - leftWidth - 4
+	return leftWidth, rightWidth, contentHeight
+}
+
 // ---------------------------------------------------------------------------
 // Tree panel (left) — groups + sessions inline
 // ---------------------------------------------------------------------------
@@ -663,10 +1308,15 @@ func (m Model) renderTreePanel(width, height int) string {
 
 	// ── Panel title ───────────────────────────────────────────────────────
 	titleIcon := "☰"
+	heading := "SESSIONS"
+	if m.flat {
+		heading = "ALL SESSIONS"
+	}
+	title := truncate(fmt.Sprintf(" %s %s%s", titleIcon, heading, m.sortLabel()), width-2)
 	if m.focus == panelTree {
-		lines = append(lines, panelTitleStyle.Render(fmt.Sprintf(" %s SESSIONS", titleIcon)))
+		lines = append(lines, panelTitleStyle.Render(title))
 	} else {
-		lines = append(lines, panelTitleDimStyle.Render(fmt.Sprintf(" %s SESSIONS", titleIcon)))
+		lines = append(lines, panelTitleDimStyle.Render(title))
 	}
 
 	if len(groups) == 0 {
@@ -675,84 +1325,121 @@ func (m Model) renderTreePanel(width, height int) string {
 		lines = append(lines, dimStyle.Render("  Press 'g' to create one."))
 	}
 
-	for gi, g := range groups {
-		// ── Group header ──────────────────────────────────────────────────
-		expandIcon := "▾"
-		if !m.expanded[gi] {
-			expandIcon = "▸"
-		}
+	lines = append(lines, m.renderPinnedRows(width)...)
+	if m.flat {
+		lines = append(lines, m.renderFlatRows(width, m.flatOrder())...)
+	} else {
+		lines = append(lines, m.renderTreeRows(width)...)
+	}
+	lines = append(lines, m.renderArchivedRows(width)...)
 
-		activeCount := m.activeCountForGroup(gi)
-		total := len(g.Sessions)
+	listContent := strings.Join(lines, "\n")
+	usedRows := len(lines)
+	pad := height - 2 - usedRows
+	if pad > 0 {
+		listContent += strings.Repeat("\n", pad)
+	}
 
-		name := groupNameStyle.Render(g.Name)
-		countPart := groupCountStyle.Render(fmt.Sprintf("(%d)", total))
-		activePart := ""
-		if activeCount > 0 {
-			activePart = " " + statusRunning.Render(fmt.Sprintf("● %d", activeCount))
-		}
-		groupLine := fmt.Sprintf(" %d.%s %s %s%s", gi+1, expandIcon, name, countPart, activePart)
+	style := panelStyle.Width(width).Height(height)
+	if m.focus == panelTree && !m.interactMode {
+		style = panelActiveStyle.Width(width).Height(height)
+	}
+	return style.Render(listContent)
+}
 
-		isSelected := m.groupIdx == gi && m.sessionIdx < 0
-		if isSelected && m.focus == panelTree && !m.interactMode {
-			lines = append(lines, selectedItemStyle.Width(width-2).Render(" ›"+groupLine))
-		} else if isSelected {
-			lines = append(lines, selectedDimStyle.Render(" ›"+groupLine))
-		} else {
-			lines = append(lines, itemStyle.Render(groupLine))
-		}
+// renderTreeRows renders the grouped tree: group headers, indented by
+// nesting depth, with the sessions of expanded groups below them.
+func (m Model) renderTreeRows(width int) []string {
+	groups := m.store.Groups()
+	var lines []string
+	for _, p := range m.appendGroupTree(nil, "") {
+		gi := p.groupIdx
+		g := groups[gi]
+		indent := strings.Repeat("  ", m.store.GroupDepth(gi))
+		if p.sessionIdx < 0 {
+			// ── Group header ──────────────────────────────────────────────
+			expandIcon := "▾"
+			if !m.expanded[gi] {
+				expandIcon = "▸"
+			}
 
-		// ── Sessions under group ──────────────────────────────────────────
-		if !m.expanded[gi] {
-			continue
-		}
-		for si, s := range g.Sessions {
-			tn := tmux.SanitizeName(g.Name, s.Name)
-			isRunning := m.tmuxSessions[tn]
+			activeCount := m.activeCountForGroup(gi)
+			total := m.sessionCountForGroup(gi)
 
-			connector := "├─"
-			if si == len(g.Sessions)-1 {
-				connector = "└─"
+			name := groupNameStyle.Render(g.Name)
+			countPart := groupCountStyle.Render(fmt.Sprintf("(%d)", total))
+			activePart := ""
+			if activeCount > 0 {
+				activePart = " " + statusRunning.Render(fmt.Sprintf("● %d", activeCount))
 			}
-			connectorStr := treeConnectorStyle.Render(connector)
-
-			sessName := truncate(s.Name, width-16)
-			suffix := treeLabelStyle.Render(" claude")
+			groupLine := fmt.Sprintf(" %s%s %s %s%s", indent, expandIcon, name, countPart, activePart)
 
-			isSessSelected := m.groupIdx == gi && m.sessionIdx == si
-			var statusDot string
-			if isSessSelected {
-				statusDot = statusRunning.Render("●")
-			} else if isRunning {
-				statusDot = dimStyle.Render("●")
+			isSelected := m.groupIdx == gi && m.sessionIdx < 0
+			if isSelected && m.focus == panelTree && !m.interactMode {
+				lines = append(lines, selectedItemStyle.Width(width-2).Render(" ›"+groupLine))
+			} else if isSelected {
+				lines = append(lines, selectedDimStyle.Render(" ›"+groupLine))
 			} else {
-				statusDot = statusStopped.Render("×")
+				lines = append(lines, itemStyle.Render(groupLine))
 			}
+			continue
+		}
 
-			body := fmt.Sprintf(" %s %s %s%s", connectorStr, statusDot, sessName, suffix)
+		// ── Session under its group ───────────────────────────────────────
+		si := p.sessionIdx
+		s := g.Sessions[si]
+		isRunning := m.tmuxSessions[m.tmuxNameAt(p)]
 
-			if isSessSelected && m.focus == panelTree && !m.interactMode {
-				lines = append(lines, selectedItemStyle.Width(width-2).Render("  "+body))
-			} else if isSessSelected {
-				lines = append(lines, selectedDimStyle.Render("  "+body))
-			} else {
-				lines = append(lines, treeSessionStyle.Render("  "+body))
-			}
+		connector := "├─"
+		if order := m.sessionOrder(gi); order[len(order)-1] == si && len(m.store.ChildGroups(g.ID)) == 0 {
+			connector = "└─"
 		}
-	}
+		connectorStr := treeConnectorStyle.Render(connector)
 
-	listContent := strings.Join(lines, "\n")
-	usedRows := len(lines)
-	pad := height - 2 - usedRows
-	if pad > 0 {
-		listContent += strings.Repeat("\n", pad)
+		sessName := truncate(s.Name, max(width-16-len(indent), 8))
+		suffix := treeLabelStyle.Render(" claude") + m.sessionSuffix(p)
+
+		isSessSelected := m.groupIdx == gi && m.sessionIdx == si && !m.cursorInPinned()
+		var statusDot string
+		if isSessSelected {
+			statusDot = statusRunning.Render("●")
+		} else if isRunning {
+			statusDot = dimStyle.Render("●")
+		} else {
+			statusDot = statusStopped.Render("×")
+		}
+
+		body := fmt.Sprintf(" %s%s %s %s%s", indent, connectorStr, statusDot, sessName, suffix)
+
+		if isSessSelected && m.focus == panelTree && !m.interactMode {
+			lines = append(lines, selectedItemStyle.Width(width-2).Render("  "+body))
+		} else if isSessSelected {
+			lines = append(lines, selectedDimStyle.Render("  "+body))
+		} else {
+			lines = append(lines, treeSessionStyle.Render("  "+body))
+		}
 	}
+	return lines
+}
 
-	style := panelStyle.Width(width).Height(height)
-	if m.focus == panelTree && !m.interactMode {
-		style = panelActiveStyle.Width(width).Height(height)
+// sessionSuffix returns the pin, mark, dirty and attention markers shown
+// after a session's name.
+func (m Model) sessionSuffix(p treePos) string {
+	s := m.store.Groups()[p.groupIdx].Sessions[p.sessionIdx]
+	suffix := ""
+	if s.Pinned {
+		suffix += " " + selectArrowStyle.Render("★")
 	}
-	return style.Render(listContent)
+	if m.marked[s.ID] {
+		suffix += " " + statusWaiting.Render("✓")
+	}
+	if st, ok := m.gitStatusFor(s.Path); ok && st.Dirty > 0 {
+		suffix += " " + statusWaiting.Render("±")
+	}
+	if m.needsAttention(p) {
+		suffix += " " + statusWaiting.Render("!")
+	}
+	return suffix
 }
 
 // ---------------------------------------------------------------------------
@@ -824,10 +1511,15 @@ func (m Model) renderPreviewContent(width, maxRows int) string {
 		availableRows = 3
 	}
 
-	if len(contentLines) > availableRows {
-		moreCount := len(contentLines) - availableRows
-		contentLines = contentLines[len(contentLines)-availableRows:]
-		contentLines[0] = dimStyle.Render(fmt.Sprintf("  ↑ %d more lines above", moreCount))
+	total := len(contentLines)
+	end := total - min(m.previewScroll, max(total-availableRows, 0))
+	start := max(end-availableRows, 0)
+	contentLines = contentLines[start:end]
+	if start > 0 {
+		contentLines[0] = dimStyle.Render(fmt.Sprintf("  ↑ %d more lines above", start))
+	}
+	if end < total {
+		contentLines[len(contentLines)-1] = dimStyle.Render(fmt.Sprintf("  ↓ %d more lines below", total-end))
 	}
 
 	for i, line := range contentLines {
@@ -853,7 +1545,7 @@ func (m Model) renderGroupSummary(titleLine string, width, maxRows int) string {
 	group := m.store.Groups()[m.groupIdx]
 	activeCount := m.activeCountForGroup(m.groupIdx)
 
-	name := metaNameStyle.Render(group.Name)
+	name := metaNameStyle.Render(m.store.GroupPath(m.groupIdx))
 	var statusBadge string
 	if activeCount > 0 {
 		statusBadge = statusRunning.Render(fmt.Sprintf("● %d active", activeCount))
@@ -862,17 +1554,27 @@ func (m Model) renderGroupSummary(titleLine string, width, maxRows int) string {
 	}
 
 	line1 := " " + name + "  " + statusBadge
-	line2 := "  " + metaIconStyle.Render("📦") + " " + metaLabelStyle.Render("Sessions ") + metaValueStyle.Render(fmt.Sprintf("%d total", len(group.Sessions)))
+	direct := len(m.sessionOrder(m.groupIdx))
+	line2 := "  " + metaIconStyle.Render("📦") + " " + metaLabelStyle.Render("Sessions ") + metaValueStyle.Render(fmt.Sprintf("%d total", direct))
+	if sub := len(m.store.Subtree(m.groupIdx)) - 1; sub > 0 {
+		line2 += dimStyle.Render(fmt.Sprintf("  (+%d in %d subgroups)", m.sessionCountForGroup(m.groupIdx)-direct, sub))
+	}
+	if archived := len(group.Sessions) - direct; archived > 0 {
+		line2 += dimStyle.Render(fmt.Sprintf("  %d archived", archived))
+	}
 	line3 := "  " + metaIconStyle.Render("🕐") + " " + metaLabelStyle.Render("Created  ") + metaValueStyle.Render(group.CreatedAt.Format("2006-01-02 15:04")) +
 		dimStyle.Render("  ("+timeAgo(group.CreatedAt)+")")
 	line4 := "  " + metaTagStyle.Render("claude") + " " + metaGroupTagStyle.Render(group.Name)
+	if m.sessionCountForGroup(m.groupIdx) > 0 {
+		line3 += "\n  " + metaIconStyle.Render("💰") + " " + metaLabelStyle.Render("Usage    ") + renderUsageLine(m.groupUsage(m.groupIdx))
+	}
 
 	sep := metaSepStyle.Render(strings.Repeat("─", width))
 	body := titleLine + "\n" + line1 + "\n" + line2 + "\n" + line3 + "\n" + line4 + "\n" + sep
 
 	if len(group.Sessions) > 0 {
 		body += "\n\n" + dimStyle.Render("  ↑↓ Navigate sessions • Enter: start • i: interact")
-		body += "\n" + dimStyle.Render("  n: add session • d: delete • r: rename")
+		body += "\n" + dimStyle.Render("  n: add session • G: add subgroup • d: delete • r: rename")
 	} else {
 		body += "\n\n" + dimStyle.Render("  No sessions yet.")
 		body += "\n" + dimStyle.Render("  Press 'n' to add a session.")
@@ -889,6 +1591,8 @@ func (m Model) renderMetaHeader(sess model.Session, group model.Group, tn string
 		statusBadge = statusWaiting.Render("● interactive")
 	} else if isRunning {
 		statusBadge = statusRunning.Render("● connected")
+	} else if sess.Archived {
+		statusBadge = metaDisconnectedStyle.Render("▣ archived " + timeAgo(sess.ArchivedAt) + " · z restore")
 	} else {
 		statusBadge = metaDisconnectedStyle.Render("○ stopped")
 	}
@@ -896,16 +1600,25 @@ func (m Model) renderMetaHeader(sess model.Session, group model.Group, tn string
 
 	// ── Line 2: Path ──────────────────────────────────────────────────────
 	pathDisplay := sess.Path
-	if ep := expandPath(pathDisplay); ep != pathDisplay {
+	if ep := model.ExpandPath(pathDisplay); ep != pathDisplay {
 		pathDisplay = ep
 	}
 	line2 := "  " + metaIconStyle.Render("📁") + " " + metaValueStyle.Render(truncate(pathDisplay, width-8))
+	if st, ok := m.gitStatusFor(sess.Path); ok {
+		line2 += "\n  " + metaIconStyle.Render("⎇") + " " + renderGitLine(st)
+	}
+	if u, ok := m.usageFor(sess); ok {
+		line2 += "\n  " + metaIconStyle.Render("💰") + " " + renderUsageLine(u)
+	}
 
 	// ── Line 3: Time ──────────────────────────────────────────────────────
-	line3 := "  " + metaIconStyle.Render("⏰") + " " + metaValueStyle.Render(timeAgo(sess.CreatedAt))
+	line3 := "  " + metaIconStyle.Render("⏰") + " " + m.renderActivityLine(sess)
 
 	// ── Line 4: Tags ──────────────────────────────────────────────────────
 	line4 := "  " + metaTagStyle.Render("claude") + " " + metaGroupTagStyle.Render(group.Name)
+	for _, t := range sess.Tags {
+		line4 += " " + metaGroupTagStyle.Background(accentDimColor).Render(t)
+	}
 
 	// ── Line 5+6: Status details ──────────────────────────────────────────
 	sep := metaSepStyle.Render(strings.Repeat("─", width))
@@ -917,6 +1630,18 @@ func (m Model) renderMetaHeader(sess model.Session, group model.Group, tn string
 	}
 	line5 := metaLabelStyle.Render("  Status:  ") + connLabel
 	line6 := metaLabelStyle.Render("  Session: ") + metaValueStyle.Render(sess.SessionID)
+	switch {
+	case sess.SessionID == "" && isRunning && m.captureShared[sess.ID]:
+		line6 += statusWaiting.Render("not captured: another new conversation runs in this folder")
+	case sess.SessionID == "" && isRunning:
+		line6 += dimStyle.Render("new conversation, ID captured after the first prompt")
+	case isNewConversation(sess):
+		line6 += dimStyle.Render("  (new conversation)")
+	}
+	if len(sess.Args) > 0 || len(sess.Env) > 0 {
+		launch := strings.TrimSpace(model.FormatEnv(sess.Env) + " claude " + model.JoinWords(sess.Args))
+		line6 += "\n" + metaLabelStyle.Render("  Launch:  ") + metaValueStyle.Render(truncate(launch, width-12))
+	}
 
 	return line1 + "\n" + line2 + "\n" + line3 + "\n" + line4 + "\n" + sep + "\n" + line5 + "\n" + line6
 }
@@ -955,6 +1680,9 @@ func (m Model) renderDialog() string {
 	switch m.dialog {
 	case dialogNewGroup:
 		title := dialogTitleStyle.Render("✦ New Group")
+		if m.newGroupParent >= 0 {
+			title = dialogTitleStyle.Render("✦ New Subgroup in " + truncate(m.store.GroupPath(m.newGroupParent), 30))
+		}
 		label := dialogLabelStyle.Render("Group Name:")
 		input := m.inputs[0].View()
 		hint := dimStyle.Render("↵ confirm  esc cancel")
@@ -962,31 +1690,110 @@ func (m Model) renderDialog() string {
 
 	case dialogNewSession:
 		title := dialogTitleStyle.Render("✦ New Session")
+		if m.newTemplate != nil {
+			title = dialogTitleStyle.Render("✦ New Session from " + truncate(m.newTemplate.Name, 30))
+			if sum := m.newTemplate.Summary(); sum != "" {
+				title += "\n" + dimStyle.Render(truncate(sum, 50))
+			}
+		}
 		var fields []string
-		labels := []string{"📁 Project Path:", "🔑 Session ID / Rename:", "📝 Display Name (optional):"}
+		labels := []string{"📁 Project Path:", "🔑 Session ID / Rename (optional):", "📝 Display Name (optional):", "🌿 Worktree Branch (optional):"}
 		for i, l := range labels {
-			fields = append(fields, dialogLabelStyle.Render(l)+"\n"+m.inputs[i].View())
+			field := dialogLabelStyle.Render(l) + "\n" + m.inputs[i].View()
+			if hint := m.renderPathHint(); i == 0 && hint != "" {
+				field += "\n" + hint
+			}
+			fields = append(fields, field)
 		}
-		hint := dimStyle.Render("tab next field  ↵ confirm  esc cancel")
+		hint := dimStyle.Render("tab complete / next  ↵ confirm  esc cancel")
 		return dialogStyle.Render(title + "\n\n" + strings.Join(fields, "\n\n") + "\n\n" + hint)
 
 	case dialogDeleteConfirm:
 		title := dialogTitleStyle.Render("⚠ Confirm Delete")
-		name := ""
+		name, worktree, subgroups := "", "", 0
 		if m.deleteTarget == "group" && m.groupIdx < len(m.store.Groups()) {
-			name = m.store.Groups()[m.groupIdx].Name
+			name = m.store.GroupPath(m.groupIdx)
+			subgroups = len(m.store.Subtree(m.groupIdx)) - 1
 		} else if m.deleteTarget == "session" {
 			ss := m.store.Sessions(m.groupIdx)
 			if m.sessionIdx >= 0 && m.sessionIdx < len(ss) {
 				name = ss[m.sessionIdx].Name
+				if ss[m.sessionIdx].WorktreeOf != "" {
+					worktree = ss[m.sessionIdx].Path
+				}
 			}
 		}
 		msg := metaValueStyle.Render(fmt.Sprintf("Delete %s ", m.deleteTarget)) +
 			metaNameStyle.Render(fmt.Sprintf("'%s'", name)) +
 			metaValueStyle.Render(" ?")
-		hint := dimStyle.Render("y yes  n/esc no")
+		hint := dimStyle.Render("y yes  s yes & stop tmux  n/esc no")
+		if m.cfg.StopOnDelete {
+			hint = dimStyle.Render("y yes & stop tmux  n/esc no")
+		}
+		if m.deleteTarget == "group" {
+			sessions := m.sessionCountForGroup(m.groupIdx)
+			if subgroups > 0 {
+				msg += "\n" + dimStyle.Render(fmt.Sprintf("including %d subgroups and %d sessions", subgroups, sessions))
+			} else if sessions > 0 {
+				msg += "\n" + dimStyle.Render(fmt.Sprintf("including %d sessions", sessions))
+			}
+			if archived := m.archivedCountForGroup(m.groupIdx); archived > 0 {
+				msg += "\n" + statusWaiting.Render(fmt.Sprintf("and %d archived sessions, whose Claude session IDs are lost", archived))
+			}
+		}
+		if worktree != "" {
+			msg += "\n" + dimStyle.Render("🌿 worktree "+truncate(worktree, 44))
+			hint += "\n" + dimStyle.Render("w yes, stop tmux & remove worktree (must be clean)")
+		}
 		return dialogStyle.Render(fmt.Sprintf("%s\n\n%s\n\n%s", title, msg, hint))
 
+	case dialogTags:
+		title := dialogTitleStyle.Render("🏷 Edit Tags")
+		label := dialogLabelStyle.Render("Tags (comma separated):")
+		input := m.inputs[0].View()
+		hint := dimStyle.Render("↵ confirm  esc cancel")
+		return dialogStyle.Render(fmt.Sprintf("%s\n\n%s\n%s\n\n%s", title, label, input, hint))
+
+	case dialogBroadcast, dialogBroadcastConfirm, dialogBroadcastResult:
+		return m.renderBroadcastDialog()
+
+	case dialogComposer:
+		return m.renderComposer()
+
+	case dialogStopGroupConfirm:
+		return m.renderStopGroupDialog()
+
+	case dialogLaunchResult:
+		return m.renderLaunchResult()
+
+	case dialogOrphans, dialogAdoptOrphan:
+		return m.renderOrphansDialog()
+
+	case dialogStats:
+		return m.renderStatsDialog()
+
+	case dialogSearch:
+		return m.renderSearchDialog()
+
+	case dialogTemplates:
+		return m.renderTemplatesDialog()
+
+	case dialogClone:
+		return m.renderCloneDialog()
+
+	case dialogLaunchSettings:
+		return m.renderLaunchSettingsDialog()
+
+	case dialogSaveTemplate:
+		title := dialogTitleStyle.Render("⎘ Save as Template")
+		label := dialogLabelStyle.Render("Template name:")
+		note := dimStyle.Render("Saves the path, claude args, env and tags.")
+		hint := dimStyle.Render("↵ save  esc cancel")
+		return dialogStyle.Render(title + "\n\n" + label + "\n" + m.inputs[0].View() + "\n\n" + note + "\n\n" + hint)
+
+	case dialogSnippets, dialogSnippetParams:
+		return m.renderSnippetDialog()
+
 	case dialogRename:
 		title := dialogTitleStyle.Render(fmt.Sprintf("✎ Rename %s", m.deleteTarget))
 		label := dialogLabelStyle.Render("New name:")
@@ -1038,23 +1845,17 @@ func newInput(placeholder, hint string, width int) textinput.Model {
 	ti.Width = width
 	ti.CharLimit = 256
 	ti.PromptStyle = lipgloss.NewStyle().Foreground(accentColor)
-	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E0E0FF"))
+	ti.TextStyle = lipgloss.NewStyle().Foreground(inputColor)
 	return ti
 }
 
-func expandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		if home, err := os.UserHomeDir(); err == nil {
-			return home + path[1:]
-		}
-	}
-	return path
-}
-
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
+	if maxLen < 1 {
+		return ""
+	}
 	return s[:maxLen-1] + "…"
 }
 
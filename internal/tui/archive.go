@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectedArchived reports whether the cursor is on an archived session.
+func (m Model) selectedArchived() bool {
+	ss := m.store.Sessions(m.groupIdx)
+	return m.sessionIdx >= 0 && m.sessionIdx < len(ss) && ss[m.sessionIdx].Archived
+}
+
+// toggleArchive archives the selected session, stopping its tmux session,
+// or restores it when it is already archived.
+func (m Model) toggleArchive() (tea.Model, tea.Cmd) {
+	ss := m.store.Sessions(m.groupIdx)
+	if m.onGroupHeader() || m.sessionIdx >= len(ss) {
+		return m, nil
+	}
+	s := &m.store.Data.Groups[m.groupIdx].Sessions[m.sessionIdx]
+	if s.Archived {
+		s.Archived, s.ArchivedAt = false, time.Time{}
+		if err := m.store.Save(); err != nil {
+			m.err = err
+		}
+		m.expanded[m.groupIdx] = true
+		m.expandAncestors(m.groupIdx)
+		m.statusMsg = fmt.Sprintf("Restored %s to %s", s.Name, m.store.GroupPath(m.groupIdx))
+		return m, nil
+	}
+
+	// Keep the cursor where the session was, on the row that takes its place.
+	cur := m.currentTreeIdx()
+	j := m.jobAt(m.groupIdx, m.sessionIdx)
+	s.Archived, s.ArchivedAt = true, time.Now()
+	delete(m.marked, s.ID)
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	name := s.Name
+	if !m.showArchived {
+		if tree := m.buildTree(); len(tree) > 0 {
+			m.selectTreeIdx(tree, min(cur, len(tree)-1))
+		} else {
+			m.sessionIdx = -1
+		}
+		m.previewScroll = 0
+	}
+	if !m.tmuxSessions[j.tmuxName] {
+		m.statusMsg = fmt.Sprintf("Archived %s", name)
+		return m, nil
+	}
+	next, cmd := m.startLifecycle(actionStop, []lifecycleJob{j})
+	m = next.(Model)
+	m.statusMsg = fmt.Sprintf("Archived %s, stopping tmux...", name)
+	return m, cmd
+}
+
+// toggleArchived shows or hides the Archived section below the tree.
+func (m Model) toggleArchived() (tea.Model, tea.Cmd) {
+	m.showArchived = !m.showArchived
+	if m.showArchived {
+		m.statusMsg = fmt.Sprintf("Showing %d archived sessions", len(m.sortedSessions(true)))
+		return m, nil
+	}
+	m.statusMsg = "Archived sessions hidden"
+	if m.selectedArchived() {
+		m.sessionIdx = -1
+		if m.flat {
+			if all := m.flatOrder(); len(all) > 0 {
+				m.groupIdx, m.sessionIdx = all[0].groupIdx, all[0].sessionIdx
+			}
+		}
+	}
+	return m, nil
+}
+
+// archivedHeaderRow returns the tree index before which the Archived
+// section header is drawn, which is not a tree position of its own, or -1
+// when there is none.
+func (m Model) archivedHeaderRow() int {
+	archived := len(m.sortedSessions(true))
+	if archived == 0 {
+		return -1
+	}
+	if m.showArchived {
+		return len(m.buildTree()) - archived
+	}
+	return len(m.buildTree())
+}
+
+// renderArchivedRows renders the Archived section header and, when shown,
+// the archived sessions with their groups.
+func (m Model) renderArchivedRows(width int) []string {
+	archived := m.sortedSessions(true)
+	if len(archived) == 0 {
+		return nil
+	}
+	icon, hint := "▸", "Z show"
+	if m.showArchived {
+		icon, hint = "▾", "Z hide"
+	}
+	header := fmt.Sprintf(" %s %s %s  %s", icon, groupNameStyle.Render("Archived"),
+		groupCountStyle.Render(fmt.Sprintf("(%d)", len(archived))), dimStyle.Render(hint))
+	lines := []string{itemStyle.Render(header)}
+	if m.showArchived {
+		lines = append(lines, m.renderFlatRows(width, archived)...)
+	}
+	return lines
+}
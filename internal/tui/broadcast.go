@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type broadcastDoneMsg struct {
+	broadcast model.Broadcast
+}
+
+// broadcastTarget is a session the composer will send to.
+type broadcastTarget struct {
+	pos      treePos
+	id       string
+	name     string
+	tmuxName string
+}
+
+// broadcastTargets resolves who receives the broadcast. A tag selects every
+// session carrying it; otherwise marked sessions win over the current group
+// and the groups nested in it.
+func (m Model) broadcastTargets(tag string) ([]broadcastTarget, string) {
+	groups := m.store.Groups()
+	var out []broadcastTarget
+	add := func(gi, si int) {
+		s := groups[gi].Sessions[si]
+		if s.Archived {
+			return
+		}
+		out = append(out, broadcastTarget{
+			pos:      treePos{gi, si},
+			id:       s.ID,
+			name:     s.Name,
+			tmuxName: m.tmuxNameAt(treePos{gi, si}),
+		})
+	}
+
+	tag = strings.TrimSpace(tag)
+	switch {
+	case tag != "":
+		for gi, g := range groups {
+			for si, s := range g.Sessions {
+				if s.HasTag(tag) {
+					add(gi, si)
+				}
+			}
+		}
+		return out, "tag " + tag
+	case len(m.marked) > 0:
+		for gi, g := range groups {
+			for si, s := range g.Sessions {
+				if m.marked[s.ID] {
+					add(gi, si)
+				}
+			}
+		}
+		return out, "marked sessions"
+	case m.groupIdx < len(groups):
+		for _, p := range m.subtreeSessions(m.groupIdx) {
+			add(p.groupIdx, p.sessionIdx)
+		}
+		return out, "group " + m.store.GroupPath(m.groupIdx)
+	}
+	return nil, ""
+}
+
+func (m Model) openBroadcast() (tea.Model, tea.Cmd) {
+	if len(m.store.Groups()) == 0 {
+		m.statusMsg = "Create a group first (press g)"
+		return m, nil
+	}
+	m.dialog = dialogBroadcast
+	m.inputs = []textinput.Model{
+		newInput("Prompt", "e.g. run the tests and report", 60),
+		newInput("Tag (optional)", "leave empty for marked sessions / group", 30),
+	}
+	m.inputs[0].CharLimit = 4096
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+// submitBroadcast moves from the composer to the confirmation step.
+func (m Model) submitBroadcast() (tea.Model, tea.Cmd) {
+	prompt := strings.TrimSpace(m.inputs[0].Value())
+	if prompt == "" {
+		m.statusMsg = "Prompt cannot be empty"
+		return m, nil
+	}
+	targets, _ := m.broadcastTargets(m.inputs[1].Value())
+	if len(targets) == 0 {
+		m.statusMsg = "No target sessions"
+		return m, nil
+	}
+	m.dialog = dialogBroadcastConfirm
+	return m, nil
+}
+
+func (m Model) updateBroadcastConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Yes), msg.Type == tea.KeyEnter:
+		prompt := strings.TrimSpace(m.inputs[0].Value())
+		targets, _ := m.broadcastTargets(m.inputs[1].Value())
+		m.dialog = dialogNone
+		m.inputs = nil
+		m.statusMsg = fmt.Sprintf("Broadcasting to %d sessions...", len(targets))
+		return m, m.broadcastCmd(prompt, targets)
+	case key.Matches(msg, keys.No, keys.Escape):
+		// Back to the composer with the draft intact.
+		m.dialog = dialogBroadcast
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+// broadcastCmd sends prompt followed by Enter to every running target.
+func (m Model) broadcastCmd(prompt string, targets []broadcastTarget) tea.Cmd {
+	running := make(map[string]bool, len(m.tmuxSessions))
+	for k, v := range m.tmuxSessions {
+		running[k] = v
+	}
+	return func() tea.Msg {
+		b := model.Broadcast{Prompt: prompt, SentAt: time.Now()}
+		for _, t := range targets {
+			res := model.BroadcastTarget{SessionID: t.id, Name: t.name}
+			var err error
+			if !running[t.tmuxName] {
+				err = fmt.Errorf("not running")
+			} else if err = tmux.SendText(t.tmuxName, prompt); err == nil {
+				err = tmux.SendSpecial(t.tmuxName, "Enter")
+			}
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.Sent = true
+			}
+			b.Targets = append(b.Targets, res)
+		}
+		return broadcastDoneMsg{broadcast: b}
+	}
+}
+
+func (m Model) handleBroadcastDone(msg broadcastDoneMsg) (tea.Model, tea.Cmd) {
+	m.store.AddBroadcast(msg.broadcast)
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.lastBroadcast = &msg.broadcast
+	m.dialog = dialogBroadcastResult
+	sent := 0
+	for _, t := range msg.broadcast.Targets {
+		if t.Sent {
+			sent++
+			m.recordActivity(t.SessionID, model.EventInteract)
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Broadcast sent to %d/%d sessions", sent, len(msg.broadcast.Targets))
+	return m, nil
+}
+
+func (m Model) renderBroadcastDialog() string {
+	switch m.dialog {
+	case dialogBroadcast:
+		title := dialogTitleStyle.Render("📣 Broadcast Prompt")
+		targets, scope := m.broadcastTargets(m.inputs[1].Value())
+		fields := dialogLabelStyle.Render("Prompt:") + "\n" + m.inputs[0].View() + "\n\n" +
+			dialogLabelStyle.Render("🏷 Tag (optional):") + "\n" + m.inputs[1].View()
+		summary := metaLabelStyle.Render("Targets: ") + metaValueStyle.Render(fmt.Sprintf("%d (%s)", len(targets), scope))
+		hint := dimStyle.Render("tab next field  ↵ review  esc cancel")
+		return dialogStyle.Render(title + "\n\n" + fields + "\n\n" + summary + "\n\n" + hint)
+
+	case dialogBroadcastConfirm:
+		title := dialogTitleStyle.Render("📣 Send Broadcast?")
+		targets, scope := m.broadcastTargets(m.inputs[1].Value())
+		var lines []string
+		for _, t := range targets {
+			dot := statusRunning.Render("●")
+			if !m.tmuxSessions[t.tmuxName] {
+				dot = statusStopped.Render("×")
+			}
+			lines = append(lines, "  "+dot+" "+metaValueStyle.Render(t.name))
+		}
+		prompt := metaNameStyle.Render(truncate(strings.TrimSpace(m.inputs[0].Value()), 48))
+		body := prompt + "\n\n" + metaLabelStyle.Render(fmt.Sprintf("To %d sessions (%s):", len(targets), scope)) +
+			"\n" + strings.Join(lines, "\n")
+		hint := dimStyle.Render("× = not running, will be skipped") + "\n" + dimStyle.Render("y/↵ send  n/esc back")
+		return dialogStyle.Render(title + "\n\n" + body + "\n\n" + hint)
+
+	case dialogBroadcastResult:
+		title := dialogTitleStyle.Render("📣 Broadcast Result")
+		var lines []string
+		if b := m.lastBroadcast; b != nil {
+			for _, t := range b.Targets {
+				if t.Sent {
+					lines = append(lines, "  "+statusRunning.Render("✓")+" "+metaValueStyle.Render(t.Name))
+				} else {
+					lines = append(lines, "  "+statusStopped.Render("✗")+" "+metaValueStyle.Render(t.Name)+
+						dimStyle.Render("  "+t.Error))
+				}
+			}
+		}
+		hint := dimStyle.Render("↵/esc close")
+		return dialogStyle.Render(title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + hint)
+	}
+	return ""
+}
+
+// toggleMark adds or removes the selected session from the multi-selection.
+func (m Model) toggleMark() (tea.Model, tea.Cmd) {
+	sessions := m.store.Sessions(m.groupIdx)
+	if m.sessionIdx < 0 || m.sessionIdx >= len(sessions) {
+		return m, nil
+	}
+	id := sessions[m.sessionIdx].ID
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+	m.statusMsg = fmt.Sprintf("%d sessions marked", len(m.marked))
+	return m, nil
+}
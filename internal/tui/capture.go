@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/usage"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// captureInterval is how often the transcripts of running new conversations
+// are looked for. Claude writes a transcript once the first prompt is sent.
+const captureInterval = 2 * time.Second
+
+// captureTarget is a running session started as a new conversation, whose
+// Claude session ID is not known yet.
+type captureTarget struct {
+	id       string // Session.ID
+	path     string
+	launched time.Time
+}
+
+type capturedMsg struct {
+	ids     map[string]string // Claude session ID by Session.ID
+	started []string          // Session.ID of fresh sessions whose transcript exists
+}
+
+// freshRunning returns the running fresh sessions (see model.Session.Fresh),
+// whose transcript appears once their first prompt is sent.
+func (m Model) freshRunning() []model.Session {
+	var fresh []model.Session
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			if s.Fresh && s.SessionID != "" && m.tmuxSessions[m.tmuxNameAt(treePos{gi, si})] {
+				fresh = append(fresh, s)
+			}
+		}
+	}
+	return fresh
+}
+
+// captureTargets returns the running sessions without a Claude session ID.
+// Sessions that share a transcript folder with another one are returned
+// separately: their transcripts cannot be told apart, so they are not
+// captured until only one of them is left.
+func (m Model) captureTargets() (targets []captureTarget, shared map[string]bool) {
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			if s.SessionID != "" || s.Archived || !m.tmuxSessions[m.tmuxNameAt(treePos{gi, si})] {
+				continue
+			}
+			launched := m.activity.For(s.ID).LastLaunched
+			if launched.IsZero() {
+				continue
+			}
+			targets = append(targets, captureTarget{id: s.ID, path: s.Path, launched: launched})
+		}
+	}
+	byDir := make(map[string][]string)
+	for _, t := range targets {
+		dir, err := usage.ProjectDir(t.path)
+		if err != nil {
+			dir = t.path
+		}
+		byDir[dir] = append(byDir[dir], t.id)
+	}
+	shared = make(map[string]bool)
+	for _, ids := range byDir {
+		if len(ids) > 1 {
+			for _, id := range ids {
+				shared[id] = true
+			}
+		}
+	}
+	kept := targets[:0]
+	for _, t := range targets {
+		if !shared[t.id] {
+			kept = append(kept, t)
+		}
+	}
+	return kept, shared
+}
+
+// refreshCapture starts a background lookup of the session IDs of new
+// conversations, at most once per captureInterval. Transcripts already
+// claimed by a stored session are never captured again, and each target
+// has a transcript folder of its own. Fresh sessions are checked for their
+// transcript in the same pass.
+func (m *Model) refreshCapture() tea.Cmd {
+	if m.capturing || time.Since(m.captureAt) < captureInterval {
+		return nil
+	}
+	targets, shared := m.captureTargets()
+	m.captureShared = shared
+	fresh := m.freshRunning()
+	if len(targets) == 0 && len(fresh) == 0 {
+		return nil
+	}
+	claimed := make(map[string]bool)
+	for _, g := range m.store.Groups() {
+		for _, s := range g.Sessions {
+			claimed[s.SessionID] = true
+		}
+	}
+	m.capturing = true
+	m.captureAt = time.Now()
+	return func() tea.Msg {
+		ids := make(map[string]string)
+		for _, t := range targets {
+			id, err := usage.FirstSessionSince(t.path, t.launched, claimed)
+			if err != nil || id == "" {
+				continue
+			}
+			ids[t.id] = id
+		}
+		var started []string
+		for _, s := range fresh {
+			if _, err := usage.TranscriptPath(s.Path, s.SessionID); err == nil {
+				started = append(started, s.ID)
+			}
+		}
+		return capturedMsg{ids: ids, started: started}
+	}
+}
+
+// handleCaptured stores the captured session IDs and clears Fresh on
+// sessions whose conversation now exists, so that they are resumed from
+// then on.
+func (m Model) handleCaptured(msg capturedMsg) (tea.Model, tea.Cmd) {
+	m.capturing = false
+	if len(msg.ids) == 0 && len(msg.started) == 0 {
+		return m, nil
+	}
+	started := make(map[string]bool, len(msg.started))
+	for _, id := range msg.started {
+		started[id] = true
+	}
+	var names []string
+	changed := false
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			ss := &m.store.Data.Groups[gi].Sessions[si]
+			if started[s.ID] {
+				ss.Fresh, ss.ForkOf = false, ""
+				changed = true
+			}
+			id, ok := msg.ids[s.ID]
+			if !ok || s.SessionID != "" {
+				continue
+			}
+			ss.SessionID = id
+			names = append(names, s.Name)
+		}
+	}
+	if !changed && len(names) == 0 {
+		return m, nil
+	}
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	if len(names) == 0 {
+		return m, nil
+	}
+	if len(names) == 1 {
+		m.statusMsg = fmt.Sprintf("Captured Claude session ID of %s", names[0])
+	} else {
+		m.statusMsg = fmt.Sprintf("Captured Claude session IDs of %d sessions", len(names))
+	}
+	return m, nil
+}
+
+// isNewConversation reports whether s starts a new conversation on launch
+// rather than resuming one. It reads no files: Fresh is cleared by the
+// capture refresh once the conversation exists.
+func isNewConversation(s model.Session) bool {
+	return s.SessionID == "" || s.Fresh
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type composerSentMsg struct {
+	target  string
+	prompt  string
+	snippet string // name of the snippet sent; empty for composed prompts
+	err     error
+}
+
+// openComposer shows the multi-line prompt composer for the selected
+// running session. An unsent draft from a previous open is restored.
+func (m Model) openComposer() (tea.Model, tea.Cmd) {
+	tn := m.selectedTmuxName()
+	if tn == "" || !m.tmuxSessions[tn] {
+		m.statusMsg = "Session not running. Press Enter on tree to start."
+		return m, nil
+	}
+	ta := textarea.New()
+	ta.Placeholder = "Write a prompt… (ctrl+s to send)"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.FocusedStyle.Text = lipgloss.NewStyle().Foreground(inputColor)
+	ta.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(accentColor)
+	ta.SetValue(m.composerDraft)
+
+	m.composer = ta
+	m.composerTarget = tn
+	m.historyIdx = -1
+	m.dialog = dialogComposer
+	m.resizeComposer()
+	return m, m.composer.Focus()
+}
+
+func (m *Model) resizeComposer() {
+	m.composer.SetWidth(min(m.width-12, 86))
+	m.composer.SetHeight(max(min(m.height-16, 12), 3))
+}
+
+func (m Model) updateComposer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.composerDraft = m.composer.Value()
+		m.dialog = dialogNone
+		m.composer.Blur()
+		return m, nil
+
+	case key.Matches(msg, keys.Send):
+		prompt := m.composer.Value()
+		if strings.TrimSpace(prompt) == "" {
+			return m, nil
+		}
+		m.statusMsg = "Sending prompt..."
+		return m, sendPromptCmd(m.composerTarget, prompt, "")
+
+	case key.Matches(msg, keys.HistoryPrev):
+		m.browseHistory(1)
+		return m, nil
+
+	case key.Matches(msg, keys.HistoryNext):
+		m.browseHistory(-1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.composer, cmd = m.composer.Update(msg)
+	return m, cmd
+}
+
+// browseHistory steps through sent prompts; delta 1 goes to older entries.
+// Leaving the newest entry restores the draft being written.
+func (m *Model) browseHistory(delta int) {
+	prompts := m.history.Prompts
+	if len(prompts) == 0 {
+		return
+	}
+	if m.historyIdx == -1 {
+		m.composerDraft = m.composer.Value()
+	}
+	m.historyIdx = min(max(m.historyIdx+delta, -1), len(prompts)-1)
+	if m.historyIdx == -1 {
+		m.composer.SetValue(m.composerDraft)
+		return
+	}
+	m.composer.SetValue(prompts[len(prompts)-1-m.historyIdx])
+}
+
+// sendPromptCmd pastes prompt into the pane as one bracketed paste and
+// submits it with Enter. snippet names the snippet being sent, if any.
+func sendPromptCmd(tn, prompt, snippet string) tea.Cmd {
+	return func() tea.Msg {
+		err := tmux.PasteText(tn, prompt)
+		if err == nil {
+			err = tmux.SendSpecial(tn, "Enter")
+		}
+		return composerSentMsg{target: tn, prompt: prompt, snippet: snippet, err: err}
+	}
+}
+
+func (m Model) handleComposerSent(msg composerSentMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Send failed: %v", msg.err)
+		return m, nil
+	}
+	m.recordActivity(m.sessionIDByTmux(msg.target), model.EventInteract)
+	if msg.snippet != "" {
+		// Snippets have their own library; history keeps composed prompts.
+		m.statusMsg = fmt.Sprintf("Snippet %s sent", msg.snippet)
+		return m, nil
+	}
+	m.history.Add(msg.prompt)
+	if err := m.history.Save(); err != nil {
+		m.err = err
+	}
+	if m.dialog == dialogComposer {
+		m.composerDraft = ""
+		m.dialog = dialogNone
+		m.composer.Blur()
+	}
+	m.statusMsg = fmt.Sprintf("Prompt sent (%d lines)", strings.Count(msg.prompt, "\n")+1)
+	return m, nil
+}
+
+func (m Model) renderComposer() string {
+	title := dialogTitleStyle.Render("✎ Compose Prompt")
+	target := metaLabelStyle.Render("To: ") + metaValueStyle.Render(m.composerTarget)
+	hist := ""
+	if m.historyIdx >= 0 {
+		hist = "  " + statusWaiting.Render(fmt.Sprintf("history %d/%d", m.historyIdx+1, len(m.history.Prompts)))
+	}
+	hint := dimStyle.Render("ctrl+s send  ↵ newline  pgup/pgdn history  esc close (keeps draft)")
+	body := title + "\n" + target + hist + "\n\n" + m.composer.View() + "\n\n" + hint
+	return dialogStyle.Width(m.composer.Width() + 4).Render(body)
+}
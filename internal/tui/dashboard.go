@@ -0,0 +1,250 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type dashSource int
+
+const (
+	dashGroup dashSource = iota
+	dashPinned
+)
+
+// dashCaptureLines is how much history is captured per dashboard tile.
+const dashCaptureLines = 60
+
+// dashTiles returns the sessions shown in the dashboard: every session of
+// the selected group, or every pinned session across groups.
+func (m Model) dashTiles() []treePos {
+	var tiles []treePos
+	groups := m.store.Groups()
+	if m.dashSource == dashPinned {
+		return m.pinnedOrder()
+	}
+	if m.dashGroup < len(groups) {
+		for si, s := range groups[m.dashGroup].Sessions {
+			if !s.Archived {
+				tiles = append(tiles, treePos{m.dashGroup, si})
+			}
+		}
+	}
+	return tiles
+}
+
+func (m Model) tmuxNameAt(p treePos) string {
+	groups := m.store.Groups()
+	if p.groupIdx >= len(groups) || p.sessionIdx < 0 || p.sessionIdx >= len(groups[p.groupIdx].Sessions) {
+		return ""
+	}
+	return tmux.SanitizeName(m.store.GroupPath(p.groupIdx), groups[p.groupIdx].Sessions[p.sessionIdx].Name)
+}
+
+// dashGrid returns the number of columns and rows for n tiles.
+func dashGrid(n int) (cols, rows int) {
+	if n == 0 {
+		return 1, 1
+	}
+	cols = int(math.Ceil(math.Sqrt(float64(n))))
+	rows = (n + cols - 1) / cols
+	return cols, rows
+}
+
+// openDashboard enters dashboard mode for the selected group.
+func (m Model) openDashboard() Model {
+	m.dashboard = true
+	m.dashSource = dashGroup
+	m.dashGroup = m.groupIdx
+	m.dashIdx = 0
+	// Start on the selected session's tile when there is one.
+	for i, p := range m.dashTiles() {
+		if p.groupIdx == m.groupIdx && p.sessionIdx == m.sessionIdx {
+			m.dashIdx = i
+		}
+	}
+	m.selectDashTile()
+	return m
+}
+
+// selectDashTile moves the tree selection to the focused tile so that LIVE
+// mode and attach act on it.
+func (m *Model) selectDashTile() {
+	tiles := m.dashTiles()
+	if len(tiles) == 0 {
+		return
+	}
+	m.dashIdx = min(max(m.dashIdx, 0), len(tiles)-1)
+	m.groupIdx = tiles[m.dashIdx].groupIdx
+	m.sessionIdx = tiles[m.dashIdx].sessionIdx
+	m.expanded[m.groupIdx] = true
+}
+
+func (m Model) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tiles := m.dashTiles()
+	cols, _ := dashGrid(len(tiles))
+
+	switch {
+	case key.Matches(msg, keys.Quit):
+		m.shutdown()
+		return m, tea.Quit
+
+	case key.Matches(msg, keys.Escape, keys.Dashboard):
+		m.dashboard = false
+		return m, nil
+
+	case key.Matches(msg, keys.Help):
+		return m.openHelp(), nil
+
+	case key.Matches(msg, keys.Tab):
+		if m.dashSource == dashGroup {
+			m.dashSource = dashPinned
+		} else {
+			m.dashSource = dashGroup
+		}
+		m.dashIdx = 0
+		m.selectDashTile()
+		return m, nil
+
+	case key.Matches(msg, keys.Left):
+		m.dashIdx--
+	case key.Matches(msg, keys.Right):
+		m.dashIdx++
+	case key.Matches(msg, keys.Up):
+		m.dashIdx -= cols
+	case key.Matches(msg, keys.Down):
+		m.dashIdx += cols
+
+	case key.Matches(msg, keys.Interact):
+		if len(tiles) == 0 {
+			return m, nil
+		}
+		tn := m.selectedTmuxName()
+		if !m.tmuxSessions[tn] {
+			m.statusMsg = "Session not running. Press Enter to start."
+			return m, nil
+		}
+		m.interactMode = true
+		m.statusMsg = ""
+		m.recordActivityAt(m.groupIdx, m.sessionIdx, model.EventInteract)
+		return m, nil
+
+	case key.Matches(msg, keys.Enter):
+		if len(tiles) == 0 {
+			return m, nil
+		}
+		return m.attachSession()
+
+	case key.Matches(msg, keys.Pin):
+		return m.togglePin()
+	}
+
+	m.selectDashTile()
+	return m, nil
+}
+
+func (m Model) renderDashboard(width, height int) string {
+	tiles := m.dashTiles()
+
+	var title string
+	if m.dashSource == dashPinned {
+		title = "PINNED"
+	} else if groups := m.store.Groups(); m.dashGroup < len(groups) {
+		title = strings.ToUpper(groups[m.dashGroup].Name)
+	}
+	titleLine := panelTitleStyle.Render(fmt.Sprintf(" ▦ DASHBOARD · %s", title))
+
+	if len(tiles) == 0 {
+		msg := "  No sessions in this group."
+		if m.dashSource == dashPinned {
+			msg = "  No pinned sessions. Press 'p' on a session to pin it."
+		}
+		body := padHeight(titleLine+"\n\n"+dimStyle.Render(msg), height-2)
+		return panelActiveStyle.Width(width - 2).Height(height - 2).Render(body)
+	}
+
+	cols, rows := dashGrid(len(tiles))
+	// Each tile is a bordered box; 2 extra rows on top for the title.
+	tileW := width/cols - 2
+	tileH := (height-2)/rows - 2
+	if tileH < 3 {
+		tileH = 3
+	}
+
+	var gridRows []string
+	for r := 0; r < rows; r++ {
+		var row []string
+		for c := 0; c < cols; c++ {
+			i := r*cols + c
+			if i >= len(tiles) {
+				break
+			}
+			row = append(row, m.renderTile(i, tiles[i], tileW, tileH))
+		}
+		gridRows = append(gridRows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	grid := lipgloss.JoinVertical(lipgloss.Left, gridRows...)
+	return lipgloss.JoinVertical(lipgloss.Left, " "+titleLine, grid)
+}
+
+func (m Model) renderTile(i int, p treePos, width, height int) string {
+	groups := m.store.Groups()
+	g := groups[p.groupIdx]
+	s := g.Sessions[p.sessionIdx]
+	tn := m.tmuxNameAt(p)
+	running := m.tmuxSessions[tn]
+	focused := i == m.dashIdx
+
+	// ── Compact header: name, state, group when mixing groups ─────────────
+	var state string
+	switch {
+	case focused && m.interactMode:
+		state = statusWaiting.Render("● live")
+	case running:
+		state = statusRunning.Render("● running")
+	default:
+		state = statusStopped.Render("○ stopped")
+	}
+	header := metaNameStyle.Render(truncate(s.Name, max(width-14, 4))) + " " + state
+	if m.dashSource == dashPinned {
+		header += " " + dimStyle.Render(m.store.GroupPath(p.groupIdx))
+	}
+
+	// ── Body: tail of the pane ────────────────────────────────────────────
+	bodyRows := height - 1
+	var lines []string
+	if !running {
+		lines = []string{dimStyle.Render("▶ Enter to launch")}
+	} else if content := m.dashContent[tn]; content != "" {
+		lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+		if len(lines) > bodyRows {
+			lines = lines[len(lines)-bodyRows:]
+		}
+		for j, l := range lines {
+			if lipgloss.Width(l) > width-2 {
+				if r := []rune(l); len(r) > width-3 {
+					lines[j] = string(r[:max(width-3, 0)]) + "…"
+				}
+			}
+		}
+	} else {
+		lines = []string{dimStyle.Render("⏳ Waiting for output...")}
+	}
+	body := padHeight(header+"\n"+previewContentStyle.Render(strings.Join(lines, "\n")), height)
+
+	style := panelStyle
+	if focused && m.interactMode {
+		style = panelInteractStyle
+	} else if focused {
+		style = panelActiveStyle
+	}
+	return style.Width(width).Height(height).MaxHeight(height + 2).Render(body)
+}
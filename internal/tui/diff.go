@@ -0,0 +1,462 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"claude-session-manager/internal/git"
+	"claude-session-manager/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// diffFile is one file of a unified diff.
+type diffFile struct {
+	path   string
+	header []string // diff --git, index, ---, +++ and mode lines
+	hunks  []diffHunk
+	added  int
+	erased int
+	binary bool
+}
+
+type diffHunk struct {
+	header string // @@ -a,b +c,d @@
+	lines  []string
+}
+
+// patch returns a patch containing only hunk hi of f.
+func (f diffFile) patch(hi int) string {
+	h := f.hunks[hi]
+	return strings.Join(f.header, "\n") + "\n" + h.header + "\n" + strings.Join(h.lines, "\n") + "\n"
+}
+
+// diffState is the open diff viewer.
+type diffState struct {
+	name    string // session display name
+	dir     string
+	base    string // commit compared against
+	head    string // HEAD when the diff was loaded
+	loading bool
+	err     error
+	files   []diffFile
+	fileIdx int
+	hunkIdx int
+	scroll  int             // lines scrolled past the current hunk
+	revert  bool            // waiting for y/n to revert the current hunk
+	staged  map[string]bool // "path\x00hunk header" of hunks staged here
+}
+
+func hunkKey(f diffFile, hi int) string {
+	return f.path + "\x00" + f.hunks[hi].header
+}
+
+type diffLoadedMsg struct {
+	dir   string
+	head  string
+	files []diffFile
+	err   error
+}
+
+type hunkAppliedMsg struct {
+	key    string // hunkKey of the applied hunk
+	revert bool
+	err    error
+}
+
+// parseDiff splits git diff output into files and hunks.
+func parseDiff(out string) []diffFile {
+	var files []diffFile
+	var f *diffFile
+	var h *diffHunk
+	if out == "" {
+		return nil
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, diffFile{header: []string{line}})
+			f, h = &files[len(files)-1], nil
+			if i := strings.LastIndex(line, " b/"); i >= 0 {
+				f.path = line[i+3:]
+			}
+		case f == nil:
+		case strings.HasPrefix(line, "@@"):
+			f.hunks = append(f.hunks, diffHunk{header: line})
+			h = &f.hunks[len(f.hunks)-1]
+		case h != nil:
+			h.lines = append(h.lines, line)
+			switch {
+			case strings.HasPrefix(line, "+"):
+				f.added++
+			case strings.HasPrefix(line, "-"):
+				f.erased++
+			}
+		default:
+			f.header = append(f.header, line)
+			if strings.HasPrefix(line, "Binary files ") {
+				f.binary = true
+			}
+		}
+	}
+	return files
+}
+
+// recordBaseline stores HEAD of the session's path as its diff baseline.
+// It is called whenever the session is launched.
+func (m *Model) recordBaseline(gi, si int) {
+	s := &m.store.Data.Groups[gi].Sessions[si]
+	head, err := git.HeadCommit(model.ExpandPath(s.Path))
+	if err != nil || head == s.BaseCommit {
+		return
+	}
+	s.BaseCommit = head
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+}
+
+// openDiff shows the diff viewer for the selected session.
+func (m Model) openDiff() (tea.Model, tea.Cmd) {
+	sessions := m.store.Sessions(m.groupIdx)
+	if m.sessionIdx < 0 || m.sessionIdx >= len(sessions) {
+		return m, nil
+	}
+	sess := sessions[m.sessionIdx]
+	dir := model.ExpandPath(sess.Path)
+	if _, ok := m.gitStatusFor(sess.Path); !ok {
+		if _, err := git.RepoRoot(dir); err != nil {
+			m.statusMsg = "Not a git repository: " + dir
+			return m, nil
+		}
+	}
+	base := sess.BaseCommit
+	if base == "" {
+		base = "HEAD"
+	}
+	m.diff = &diffState{name: sess.Name, dir: dir, base: base, loading: true, staged: make(map[string]bool)}
+	return m, loadDiffCmd(dir, base)
+}
+
+func loadDiffCmd(dir, base string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := git.Diff(dir, base)
+		head, _ := git.HeadCommit(dir)
+		return diffLoadedMsg{dir: dir, head: head, files: parseDiff(out), err: err}
+	}
+}
+
+func (m Model) handleDiffLoaded(msg diffLoadedMsg) (tea.Model, tea.Cmd) {
+	d := m.diff
+	if d == nil || d.dir != msg.dir {
+		return m, nil
+	}
+	d.loading = false
+	d.head = msg.head
+	d.err = msg.err
+	d.files = msg.files
+	d.fileIdx = min(d.fileIdx, max(len(d.files)-1, 0))
+	d.clampHunk()
+	return m, nil
+}
+
+// moved reports whether HEAD has left the baseline. Hunks then include
+// committed changes and cannot be applied to the index, which is at HEAD.
+func (d *diffState) moved() bool {
+	return d.base != "HEAD" && d.head != "" && d.head != d.base
+}
+
+func (d *diffState) clampHunk() {
+	n := 0
+	if d.fileIdx < len(d.files) {
+		n = len(d.files[d.fileIdx].hunks)
+	}
+	d.hunkIdx = min(d.hunkIdx, max(n-1, 0))
+	d.scroll = 0
+}
+
+// applyHunkCmd stages the current hunk, or reverts it in the work tree.
+func (d *diffState) applyHunkCmd(revert bool) tea.Cmd {
+	if d.fileIdx >= len(d.files) || d.hunkIdx >= len(d.files[d.fileIdx].hunks) {
+		return nil
+	}
+	f := d.files[d.fileIdx]
+	dir, patch, hk := d.dir, f.patch(d.hunkIdx), hunkKey(f, d.hunkIdx)
+	return func() tea.Msg {
+		return hunkAppliedMsg{key: hk, revert: revert, err: git.ApplyPatch(dir, patch, !revert, revert)}
+	}
+}
+
+func (m Model) handleHunkApplied(msg hunkAppliedMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	case msg.revert:
+		m.statusMsg = "Hunk reverted"
+	default:
+		m.statusMsg = "Hunk staged"
+	}
+	if m.diff == nil {
+		return m, nil
+	}
+	// Staging leaves the diff against the baseline unchanged, so the hunk
+	// is only marked; reverting removes it, so reload.
+	if !msg.revert {
+		m.diff.staged[msg.key] = true
+		return m, nil
+	}
+	return m, loadDiffCmd(m.diff.dir, m.diff.base)
+}
+
+func (m Model) updateDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.diff
+	if d.revert {
+		d.revert = false
+		if key.Matches(msg, keys.Yes) {
+			return m, d.applyHunkCmd(true)
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Escape, keys.Diff, keys.Quit):
+		m.diff = nil
+	case key.Matches(msg, keys.Help):
+		return m.openHelp(), nil
+	case key.Matches(msg, keys.Right):
+		if d.fileIdx < len(d.files)-1 {
+			d.fileIdx++
+			d.hunkIdx = 0
+			d.clampHunk()
+		}
+	case key.Matches(msg, keys.Left):
+		if d.fileIdx > 0 {
+			d.fileIdx--
+			d.hunkIdx = 0
+			d.clampHunk()
+		}
+	case key.Matches(msg, keys.Down):
+		d.hunkIdx++
+		d.clampHunk()
+	case key.Matches(msg, keys.Up):
+		d.hunkIdx = max(d.hunkIdx-1, 0)
+		d.scroll = 0
+	case key.Matches(msg, keys.HistoryNext):
+		d.scroll += 10
+	case key.Matches(msg, keys.HistoryPrev):
+		d.scroll = max(d.scroll-10, 0)
+	case key.Matches(msg, keys.StageHunk):
+		if d.moved() {
+			m.statusMsg = "Cannot stage: the session committed since launch, so hunks no longer apply to the index"
+			return m, nil
+		}
+		m.statusMsg = "Staging hunk..."
+		return m, d.applyHunkCmd(false)
+	case key.Matches(msg, keys.RevertHunk):
+		if d.fileIdx < len(d.files) && len(d.files[d.fileIdx].hunks) > 0 {
+			d.revert = true
+		}
+	case key.Matches(msg, keys.ReloadDiff):
+		d.loading = true
+		return m, loadDiffCmd(d.dir, d.base)
+	}
+	return m, nil
+}
+
+// ---------------------------------------------------------------------------
+// Rendering
+// ---------------------------------------------------------------------------
+
+func (m Model) renderDiff(width, height int) string {
+	d := m.diff
+	base := d.base
+	if len(base) > 7 && base != "HEAD" {
+		base = base[:7]
+	}
+	added, erased := 0, 0
+	for _, f := range d.files {
+		added += f.added
+		erased += f.erased
+	}
+	title := panelTitleStyle.MarginBottom(0).Render(fmt.Sprintf(" ± DIFF · %s", d.name)) + "\n" +
+		dimStyle.Render(fmt.Sprintf("  vs %s · %d files ", base, len(d.files))) +
+		diffAddStyle.Render(fmt.Sprintf("+%d", added)) + " " + diffDelStyle.Render(fmt.Sprintf("-%d", erased))
+	if d.moved() {
+		title += dimStyle.Render(" · committed since launch, staging off")
+	}
+
+	innerH := height - 2
+	var body string
+	switch {
+	case d.loading:
+		body = dimStyle.Render("  ⏳ Loading diff...")
+	case d.err != nil:
+		body = errorStyle.Render("  ✗ " + d.err.Error())
+	case len(d.files) == 0:
+		body = dimStyle.Render("  No changes since " + base)
+	default:
+		listW := min(36, width/3)
+		list := m.renderDiffFiles(listW, innerH-3)
+		hunks := m.renderDiffHunks(width-listW-6, innerH-3)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, list, "  ", hunks)
+	}
+	content := padHeight(title+"\n\n"+body, innerH)
+	return panelActiveStyle.Width(width - 2).Height(innerH).Render(content)
+}
+
+func (m Model) renderDiffFiles(width, height int) string {
+	d := m.diff
+	start := max(min(d.fileIdx-height/2, len(d.files)-height), 0)
+	var lines []string
+	for i := start; i < len(d.files) && i < start+height; i++ {
+		f := d.files[i]
+		stat := diffAddStyle.Render(fmt.Sprintf("+%d", f.added)) + " " + diffDelStyle.Render(fmt.Sprintf("-%d", f.erased))
+		if f.binary {
+			stat = dimStyle.Render("bin")
+		}
+		name := truncate(filepath.ToSlash(f.path), width-12)
+		if i == d.fileIdx {
+			lines = append(lines, selectArrowStyle.Render("› ")+metaNameStyle.Render(name)+" "+stat)
+		} else {
+			lines = append(lines, "  "+metaValueStyle.Render(name)+" "+stat)
+		}
+	}
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderDiffHunks(width, height int) string {
+	d := m.diff
+	f := d.files[d.fileIdx]
+	lang := languageFor(f.path)
+
+	var lines []string
+	offset := 0
+	lines = append(lines, diffMetaStyle.Render(f.path))
+	if f.binary {
+		lines = append(lines, dimStyle.Render("Binary file"))
+	}
+	for hi, h := range f.hunks {
+		marker := "  "
+		if hi == d.hunkIdx {
+			marker = selectArrowStyle.Render("▶ ")
+			offset = len(lines)
+		}
+		header := marker + diffHunkStyle.Render(h.header)
+		if d.staged[hunkKey(f, hi)] {
+			header += " " + statusRunning.Render("✓ staged")
+		}
+		lines = append(lines, header)
+		for _, l := range h.lines {
+			lines = append(lines, "  "+renderDiffLine(l, lang))
+		}
+	}
+
+	hint := ""
+	if d.revert {
+		hint = errorStyle.Render("Revert this hunk in the work tree? y/n")
+	}
+	height--
+	start := max(min(offset+d.scroll, len(lines)-height), 0)
+	end := min(start+height, len(lines))
+	clip := lipgloss.NewStyle().MaxWidth(width)
+	var out []string
+	for _, l := range lines[start:end] {
+		out = append(out, clip.Render(l))
+	}
+	return padHeight(strings.Join(out, "\n"), height) + "\n" + hint
+}
+
+// renderDiffLine colors the +/- marker and highlights the code after it.
+func renderDiffLine(line string, lang *langSpec) string {
+	if line == "" {
+		return ""
+	}
+	marker, code := line[:1], strings.ReplaceAll(line[1:], "\t", "    ")
+	base := metaValueStyle
+	switch marker {
+	case "+":
+		base = diffAddStyle
+	case "-":
+		base = diffDelStyle
+	case "\\":
+		return dimStyle.Render(line)
+	}
+	return base.Render(marker) + highlight(code, lang, base)
+}
+
+// ---------------------------------------------------------------------------
+// Syntax highlighting
+// ---------------------------------------------------------------------------
+
+// langSpec is a minimal tokenizer for one language family: enough to pick
+// out keywords, string literals and line comments in a diff.
+type langSpec struct {
+	token *regexp.Regexp // groups: 1 comment, 2 string, 3 keyword
+}
+
+func newLang(comment string, keywords ...string) *langSpec {
+	return &langSpec{token: regexp.MustCompile(
+		`(` + comment + `.*$)` +
+			"|(\"(?:[^\"\\\\]|\\\\.)*\"|'(?:[^'\\\\]|\\\\.)*'|`[^`]*`)" +
+			`|\b(` + strings.Join(keywords, "|") + `)\b`)}
+}
+
+var (
+	langGo = newLang("//", "break", "case", "chan", "const", "continue", "default", "defer", "else",
+		"fallthrough", "for", "func", "go", "goto", "if", "import", "interface", "map", "package",
+		"range", "return", "select", "struct", "switch", "type", "var", "nil", "true", "false")
+	langC = newLang("//", "break", "case", "catch", "class", "const", "continue", "default", "do",
+		"else", "enum", "export", "extends", "false", "fn", "for", "function", "if", "impl", "import",
+		"interface", "let", "match", "mut", "new", "null", "pub", "public", "private", "return",
+		"static", "struct", "switch", "this", "throw", "true", "try", "type", "use", "var", "void", "while")
+	langScript = newLang("#", "and", "as", "class", "def", "do", "elif", "else", "end", "esac",
+		"except", "False", "fi", "for", "from", "function", "if", "import", "in", "lambda", "None",
+		"not", "or", "pass", "raise", "return", "then", "True", "try", "while", "with", "yield")
+)
+
+var langByExt = map[string]*langSpec{
+	".go": langGo,
+	".c":  langC, ".h": langC, ".cc": langC, ".cpp": langC, ".hpp": langC, ".java": langC,
+	".js": langC, ".jsx": langC, ".ts": langC, ".tsx": langC, ".rs": langC, ".swift": langC, ".kt": langC,
+	".py": langScript, ".rb": langScript, ".sh": langScript, ".bash": langScript, ".zsh": langScript,
+	".yaml": langScript, ".yml": langScript, ".toml": langScript,
+}
+
+// languageFor returns the tokenizer for path, or nil for plain text.
+func languageFor(path string) *langSpec {
+	return langByExt[strings.ToLower(filepath.Ext(path))]
+}
+
+// highlight renders code with base, picking out comments, strings and
+// keywords when lang is known.
+func highlight(code string, lang *langSpec, base lipgloss.Style) string {
+	if lang == nil {
+		return base.Render(code)
+	}
+	var b strings.Builder
+	last := 0
+	for _, mt := range lang.token.FindAllStringSubmatchIndex(code, -1) {
+		if mt[0] > last {
+			b.WriteString(base.Render(code[last:mt[0]]))
+		}
+		tok := code[mt[0]:mt[1]]
+		switch {
+		case mt[2] >= 0:
+			b.WriteString(synCommentStyle.Render(tok))
+		case mt[4] >= 0:
+			b.WriteString(synStringStyle.Render(tok))
+		default:
+			b.WriteString(synKeywordStyle.Render(tok))
+		}
+		last = mt[1]
+	}
+	if last < len(code) {
+		b.WriteString(base.Render(code[last:]))
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package tui
+
+import "testing"
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@ package main
+ package main
+-var a = 1
++var a = 2
+ var b = 3
+@@ -10,2 +10,3 @@ func f() {
+ 	x()
++	y()
+ }
+diff --git a/logo.png b/logo.png
+index 3333333..4444444 100644
+Binary files a/logo.png and b/logo.png differ
+diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..5555555
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++hello
+`
+
+func TestParseDiff(t *testing.T) {
+	files := parseDiff(sampleDiff)
+	if len(files) != 3 {
+		t.Fatalf("files = %d, want 3", len(files))
+	}
+
+	tests := []struct {
+		path           string
+		hunks          int
+		added, erased  int
+		binary         bool
+		headerLines    int
+		firstHunkLines int
+	}{
+		{path: "main.go", hunks: 2, added: 2, erased: 1, headerLines: 4, firstHunkLines: 4},
+		{path: "logo.png", binary: true, headerLines: 3},
+		{path: "new.txt", hunks: 1, added: 1, headerLines: 5, firstHunkLines: 1},
+	}
+	for i, tt := range tests {
+		f := files[i]
+		if f.path != tt.path {
+			t.Errorf("file %d: path = %q, want %q", i, f.path, tt.path)
+		}
+		if len(f.hunks) != tt.hunks || f.added != tt.added || f.erased != tt.erased || f.binary != tt.binary {
+			t.Errorf("%s: hunks/added/erased/binary = %d/%d/%d/%v, want %d/%d/%d/%v", tt.path,
+				len(f.hunks), f.added, f.erased, f.binary, tt.hunks, tt.added, tt.erased, tt.binary)
+		}
+		if len(f.header) != tt.headerLines {
+			t.Errorf("%s: header = %q, want %d lines", tt.path, f.header, tt.headerLines)
+		}
+		if tt.hunks > 0 && len(f.hunks[0].lines) != tt.firstHunkLines {
+			t.Errorf("%s: first hunk = %q, want %d lines", tt.path, f.hunks[0].lines, tt.firstHunkLines)
+		}
+	}
+}
+
+func TestParseDiffEmpty(t *testing.T) {
+	if files := parseDiff(""); files != nil {
+		t.Errorf("parseDiff(\"\") = %v, want nil", files)
+	}
+	if files := parseDiff("warning: something\n"); files != nil {
+		t.Errorf("lines before the first file = %v, want nil", files)
+	}
+}
+
+func TestDiffFilePatch(t *testing.T) {
+	f := parseDiff(sampleDiff)[0]
+	want := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -10,2 +10,3 @@ func f() {
+ 	x()
++	y()
+ }
+`
+	if got := f.patch(1); got != want {
+		t.Errorf("patch(1) =\n%s\nwant\n%s", got, want)
+	}
+	if got, want := hunkKey(f, 0), "main.go\x00@@ -1,3 +1,3 @@ package main"; got != want {
+		t.Errorf("hunkKey = %q, want %q", got, want)
+	}
+}
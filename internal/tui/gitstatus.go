@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"claude-session-manager/internal/git"
+	"claude-session-manager/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gitStatusTTL is how long a cached git status is shown before it is
+// refreshed in the background.
+const gitStatusTTL = 5 * time.Second
+
+// gitEntry is the cached git status of one session path. ok is false when
+// the path is not inside a git work tree.
+type gitEntry struct {
+	status  git.Status
+	ok      bool
+	fetched time.Time
+}
+
+type gitStatusMsg struct {
+	entries map[string]gitEntry // by expanded path
+}
+
+// staleGitPaths returns the expanded paths of visible sessions whose git
+// status is missing or older than gitStatusTTL and not already being read.
+func (m Model) staleGitPaths() []string {
+	now := time.Now()
+	seen := make(map[string]bool)
+	var paths []string
+	for gi, g := range m.store.Groups() {
+		if !m.expanded[gi] && gi != m.groupIdx {
+			continue
+		}
+		for _, s := range g.Sessions {
+			p := model.ExpandPath(s.Path)
+			if seen[p] || m.gitPending[p] {
+				continue
+			}
+			seen[p] = true
+			if e, ok := m.gitCache[p]; ok && now.Sub(e.fetched) < gitStatusTTL {
+				continue
+			}
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// refreshGitStatus starts a background read of every stale path.
+func (m *Model) refreshGitStatus() tea.Cmd {
+	paths := m.staleGitPaths()
+	if len(paths) == 0 {
+		return nil
+	}
+	for _, p := range paths {
+		m.gitPending[p] = true
+	}
+	return func() tea.Msg {
+		entries := make(map[string]gitEntry, len(paths))
+		for _, p := range paths {
+			st, err := git.GetStatus(p)
+			entries[p] = gitEntry{status: st, ok: err == nil, fetched: time.Now()}
+		}
+		return gitStatusMsg{entries: entries}
+	}
+}
+
+func (m Model) handleGitStatus(msg gitStatusMsg) (tea.Model, tea.Cmd) {
+	for p, e := range msg.entries {
+		m.gitCache[p] = e
+		delete(m.gitPending, p)
+	}
+	return m, nil
+}
+
+// gitStatusFor returns the cached status of a session path.
+func (m Model) gitStatusFor(path string) (git.Status, bool) {
+	e, ok := m.gitCache[model.ExpandPath(path)]
+	return e.status, ok && e.ok
+}
+
+// renderGitLine formats branch, divergence and dirty count for the metadata
+// header.
+func renderGitLine(st git.Status) string {
+	branch := st.Branch
+	if st.Detached {
+		branch += " (detached)"
+	}
+	line := metaValueStyle.Render(branch)
+	if st.Ahead > 0 {
+		line += " " + statusRunning.Render(fmt.Sprintf("↑%d", st.Ahead))
+	}
+	if st.Behind > 0 {
+		line += " " + statusWaiting.Render(fmt.Sprintf("↓%d", st.Behind))
+	}
+	if st.Dirty > 0 {
+		line += " " + statusWaiting.Render(fmt.Sprintf("± %d changed", st.Dirty))
+	} else {
+		line += " " + dimStyle.Render("clean")
+	}
+	return line
+}
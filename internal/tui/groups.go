@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openNewGroup shows the new group dialog. With parent >= 0 the group is
+// created inside that group.
+func (m Model) openNewGroup(parent int) (tea.Model, tea.Cmd) {
+	m.dialog = dialogNewGroup
+	m.newGroupParent = parent
+	m.inputs = []textinput.Model{newInput("Group name", "e.g. Work or org/repo", 30)}
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+// submitNewGroup creates the group named in the dialog. A name like
+// "org/repo" creates the nested groups along the path, reusing those that
+// already exist.
+func (m Model) submitNewGroup() (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(m.inputs[0].Value())
+	if strings.Trim(name, "/ ") == "" {
+		m.statusMsg = "Group name cannot be empty"
+		return m, nil
+	}
+	path := name
+	if m.newGroupParent >= 0 && m.newGroupParent < len(m.store.Groups()) {
+		path = m.store.GroupPath(m.newGroupParent) + "/" + name
+	}
+	before := len(m.store.Groups())
+	idx := m.store.EnsureGroupPath(path)
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.groupIdx = idx
+	m.sessionIdx = -1
+	m.expanded[idx] = true
+	m.expandAncestors(idx)
+	if len(m.store.Groups()) == before {
+		m.statusMsg = fmt.Sprintf("Group already exists: %s", m.store.GroupPath(idx))
+	} else {
+		m.statusMsg = fmt.Sprintf("Created group: %s", m.store.GroupPath(idx))
+	}
+	m.dialog = dialogNone
+	m.inputs = nil
+	return m, nil
+}
+
+// expandAncestors expands every group enclosing gi so that it is visible in
+// the tree.
+func (m *Model) expandAncestors(gi int) {
+	groups := m.store.Groups()
+	for p := groups[gi].Parent; p != ""; {
+		pi := -1
+		for i, g := range groups {
+			if g.ID == p {
+				pi = i
+				break
+			}
+		}
+		if pi < 0 {
+			return
+		}
+		m.expanded[pi] = true
+		p = groups[pi].Parent
+	}
+}
+
+// expandedIDs returns the IDs of the expanded groups. Group indexes shift
+// when groups are deleted, so expansion is carried across a delete by ID.
+func (m Model) expandedIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for gi, g := range m.store.Groups() {
+		if m.expanded[gi] {
+			ids[g.ID] = true
+		}
+	}
+	return ids
+}
+
+// setExpandedIDs rebuilds the expansion map from group IDs.
+func (m *Model) setExpandedIDs(ids map[string]bool) {
+	m.expanded = make(map[int]bool)
+	for gi, g := range m.store.Groups() {
+		m.expanded[gi] = ids[g.ID]
+	}
+}
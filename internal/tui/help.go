@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpSection is a titled group of bindings shown in the help overlay.
+type helpSection struct {
+	title    string
+	hint     string
+	bindings []key.Binding
+	notes    []string // free-form lines for actions without a key binding
+}
+
+// helpSections lists bindings by mode. The section matching the context the
+// overlay was opened from comes first.
+func (m Model) helpSections() []helpSection {
+	tree := helpSection{
+		title: "Tree",
+		hint:  "Left panel: browse groups and sessions",
+		bindings: []key.Binding{
+			keys.Up, keys.Down, keys.Tab,
+			withHelp(keys.Enter, "expand group / select session"),
+			keys.Interact, keys.NewGrp, keys.SubGrp, keys.NewSess, keys.FromTmpl, keys.Clone,
+			keys.Template, keys.LaunchCfg, keys.Delete, keys.Rename,
+			keys.Stop, keys.Restart, keys.Launch, keys.Orphans, keys.Pin, keys.Jump, keys.Mark, withHelp(keys.Escape, "clear marks"), keys.Tags,
+			keys.Compose, keys.Snippets, keys.Broadcast, keys.Dashboard, keys.Diff, keys.Stats, keys.Sort, keys.Flat,
+			keys.Archive, keys.Archived, keys.Search, keys.Help, keys.Quit,
+		},
+	}
+	preview := helpSection{
+		title: "Preview",
+		hint:  "Right panel: live output of the selected session",
+		bindings: []key.Binding{
+			keys.Tab,
+			withHelp(keys.Enter, "attach full tmux session"),
+			withHelp(keys.Interact, "enter LIVE mode"),
+			keys.Compose, keys.Snippets, keys.Help, keys.Quit,
+		},
+	}
+	live := helpSection{
+		title: "LIVE mode",
+		hint:  "All other keys are forwarded to Claude; pastes arrive as one bracketed paste",
+		bindings: []key.Binding{
+			keys.ExitLive,
+		},
+	}
+	dialogs := helpSection{
+		title: "Dialogs",
+		hint:  "New group / session, rename, tags, broadcast, snippets, confirmations",
+		bindings: []key.Binding{
+			keys.NextField, keys.PrevField,
+			withHelp(keys.NextField, "complete directory (path field)"), keys.UseRoot,
+			withHelp(keys.Enter, "confirm"),
+			withHelp(keys.Escape, "cancel"),
+			keys.Yes, keys.No, keys.StopToo, keys.Worktree,
+		},
+	}
+
+	orphans := helpSection{
+		title: "Orphaned sessions",
+		hint:  "claude_* tmux sessions that match no stored session",
+		bindings: []key.Binding{
+			keys.Up, keys.Down,
+			withHelp(keys.Enter, "attach"),
+			keys.Adopt,
+			withHelp(keys.Stop, "kill"),
+			withHelp(keys.Escape, "close"),
+		},
+	}
+	composer := helpSection{
+		title: "Prompt composer",
+		hint:  "Multi-line prompt delivered in one paste",
+		bindings: []key.Binding{
+			keys.Send, withHelp(keys.Enter, "new line"),
+			keys.HistoryPrev, keys.HistoryNext,
+			withHelp(keys.Escape, "close, keep draft"),
+		},
+	}
+	dashboard := helpSection{
+		title: "Dashboard",
+		hint:  "Tiled live panes of a group or of pinned sessions",
+		bindings: []key.Binding{
+			withHelp(keys.Up, "tile up"), withHelp(keys.Down, "tile down"),
+			withHelp(keys.Left, "tile left"), withHelp(keys.Right, "tile right"),
+			withHelp(keys.Interact, "LIVE mode on tile"),
+			withHelp(keys.Enter, "launch/attach tile"),
+			withHelp(keys.Tab, "group / pinned"),
+			keys.Pin,
+			withHelp(keys.Escape, "close dashboard"),
+		},
+	}
+	diff := helpSection{
+		title: "Diff viewer",
+		hint:  "Changes in the session's repo since it was launched",
+		bindings: []key.Binding{
+			withHelp(keys.Left, "previous file"), withHelp(keys.Right, "next file"),
+			withHelp(keys.Up, "previous hunk"), withHelp(keys.Down, "next hunk"),
+			withHelp(keys.HistoryPrev, "scroll up"), withHelp(keys.HistoryNext, "scroll down"),
+			keys.StageHunk, keys.RevertHunk, keys.ReloadDiff,
+			withHelp(keys.Escape, "close diff"),
+		},
+	}
+	mouse := helpSection{
+		title: "Mouse",
+		hint:  "Not available while a dialog is open",
+		notes: []string{
+			"click           select tree row / focus preview",
+			"double-click    launch or attach session, toggle group",
+			"wheel           move in tree, scroll preview history",
+			"drag divider    resize the tree panel",
+		},
+	}
+
+	switch {
+	case m.diff != nil:
+		return []helpSection{diff, tree, preview, live, composer, dashboard, dialogs, orphans, mouse}
+	case m.dashboard:
+		return []helpSection{dashboard, live, tree, preview, composer, dialogs, orphans, diff, mouse}
+	case m.focus == panelPreview:
+		return []helpSection{preview, live, composer, tree, dashboard, dialogs, orphans, diff, mouse}
+	}
+	return []helpSection{tree, preview, live, composer, dashboard, dialogs, orphans, diff, mouse}
+}
+
+// openHelp shows the help overlay sized to the current window.
+func (m Model) openHelp() Model {
+	m.showHelp = true
+	m.helpView = viewport.New(0, 0)
+	m.resizeHelp()
+	m.helpView.GotoTop()
+	return m
+}
+
+func (m *Model) resizeHelp() {
+	w, h := m.helpSize()
+	m.helpView.Width = w
+	m.helpView.Height = h
+	m.helpView.SetContent(m.renderHelpContent(w))
+}
+
+// helpSize returns the viewport size of the help overlay.
+func (m Model) helpSize() (int, int) {
+	w := min(m.width-8, 72)
+	h := m.height - 10
+	return max(w, 20), max(h, 3)
+}
+
+func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, keys.Escape, keys.Help, keys.Quit) {
+		m.showHelp = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.helpView, cmd = m.helpView.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderHelpContent(width int) string {
+	h := help.New()
+	h.Width = width
+	h.Styles.FullKey = lipgloss.NewStyle().Foreground(activeColor).Bold(true)
+	h.Styles.FullDesc = metaValueStyle
+	h.Styles.FullSeparator = dimStyle
+
+	var b strings.Builder
+	for i, sec := range m.helpSections() {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		title := sec.title
+		if i == 0 {
+			title += " (current)"
+		}
+		b.WriteString(dialogLabelStyle.Bold(true).Render(title))
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render(sec.hint))
+		b.WriteString("\n")
+		if len(sec.bindings) > 0 {
+			half := (len(sec.bindings) + 1) / 2
+			b.WriteString(h.FullHelpView([][]key.Binding{sec.bindings[:half], sec.bindings[half:]}))
+		}
+		for j, n := range sec.notes {
+			if j > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(metaValueStyle.Render(n))
+		}
+	}
+	return b.String()
+}
+
+func (m Model) renderHelp() string {
+	title := dialogTitleStyle.Render("? Help")
+	hint := dimStyle.Render("↑↓ scroll  esc/? close")
+	if !m.helpView.AtTop() || !m.helpView.AtBottom() {
+		hint += "    " + metaLabelStyle.Render(fmt.Sprintf("%d%%", int(m.helpView.ScrollPercent()*100)))
+	}
+	body := title + "\n" + m.helpView.View() + "\n\n" + hint
+	return dialogStyle.Width(m.helpView.Width + 4).Render(body)
+}
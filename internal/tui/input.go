@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+
+	"claude-session-manager/internal/tmux"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inputBacklogWarn is the queue depth at which LIVE mode reports that tmux
+// is not keeping up with typing.
+const inputBacklogWarn = 32
+
+// enqueueKey queues a keystroke for tn and schedules a flush. Flushes are
+// serialized inside the queue, so keys reach tmux in the order typed no
+// matter how the resulting commands are scheduled.
+func (m Model) enqueueKey(tn string, k tmux.Key) (tea.Model, tea.Cmd) {
+	var closeOld tea.Cmd
+	if m.inputQueue == nil || m.inputQueue.Target() != tn {
+		closeOld = m.closeInputQueue()
+		m.inputQueue = tmux.NewInputQueue(tn)
+	}
+	q := m.inputQueue
+	if depth := q.Enqueue(k); depth >= inputBacklogWarn {
+		m.inputBacklog = true
+		m.statusMsg = fmt.Sprintf("⏳ Input backlog: %d keys waiting for tmux", depth)
+	}
+	flush := func() tea.Msg {
+		return inputFlushedMsg{target: q.Target(), res: q.Flush()}
+	}
+	return m, tea.Batch(closeOld, flush)
+}
+
+func (m Model) handleInputFlushed(msg inputFlushedMsg) (tea.Model, tea.Cmd) {
+	if msg.res.Err != nil {
+		m.statusMsg = fmt.Sprintf("Send failed: %v", msg.res.Err)
+		return m, nil
+	}
+	if m.inputBacklog && m.inputQueue != nil && m.inputQueue.Pending() == 0 {
+		m.inputBacklog = false
+		m.statusMsg = ""
+	}
+	return m, nil
+}
+
+// closeInputQueue detaches the current queue's control client in the
+// background and forgets the queue.
+func (m *Model) closeInputQueue() tea.Cmd {
+	q := m.inputQueue
+	if q == nil {
+		return nil
+	}
+	m.inputQueue = nil
+	m.inputBacklog = false
+	return func() tea.Msg {
+		q.Flush()
+		q.Close()
+		return nil
+	}
+}
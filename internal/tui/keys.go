@@ -3,21 +3,67 @@ package tui
 import "github.com/charmbracelet/bubbles/key"
 
 type keyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Tab      key.Binding
-	Enter    key.Binding
-	NewSess  key.Binding
-	NewGrp   key.Binding
-	Delete   key.Binding
-	Rename   key.Binding
-	Interact key.Binding
-	FullTmux key.Binding
-	Quit     key.Binding
-	Help     key.Binding
-	Escape   key.Binding
-	Yes      key.Binding
-	No       key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Tab       key.Binding
+	Enter     key.Binding
+	NewSess   key.Binding
+	NewGrp    key.Binding
+	SubGrp    key.Binding
+	Delete    key.Binding
+	Rename    key.Binding
+	Interact  key.Binding
+	FullTmux  key.Binding
+	Pin       key.Binding
+	Jump      key.Binding
+	Mark      key.Binding
+	Tags      key.Binding
+	Broadcast key.Binding
+	Compose   key.Binding
+	Snippets  key.Binding
+	Stop      key.Binding
+	Restart   key.Binding
+	Launch    key.Binding
+	Orphans   key.Binding
+	Adopt     key.Binding
+	Dashboard key.Binding
+	Diff      key.Binding
+	Stats     key.Binding
+	Sort      key.Binding
+	Flat      key.Binding
+	Archive   key.Binding
+	Archived  key.Binding
+	Search    key.Binding
+	Template  key.Binding
+	FromTmpl  key.Binding
+	Clone     key.Binding
+	LaunchCfg key.Binding
+	DelTmpl   key.Binding
+	Quit      key.Binding
+	Help      key.Binding
+	Escape    key.Binding
+	Yes       key.Binding
+	No        key.Binding
+	StopToo   key.Binding
+	Worktree  key.Binding
+
+	// LIVE mode and dialogs
+	ExitLive  key.Binding
+	NextField key.Binding
+	PrevField key.Binding
+	UseRoot   key.Binding
+
+	// Diff viewer
+	StageHunk  key.Binding
+	RevertHunk key.Binding
+	ReloadDiff key.Binding
+
+	// Prompt composer
+	Send        key.Binding
+	HistoryPrev key.Binding
+	HistoryNext key.Binding
 }
 
 var keys = keyMap{
@@ -29,6 +75,14 @@ var keys = keyMap{
 		key.WithKeys("down", "j"),
 		key.WithHelp("↓/j", "down"),
 	),
+	Left: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "right"),
+	),
 	Tab: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "switch panel"),
@@ -45,6 +99,10 @@ var keys = keyMap{
 		key.WithKeys("g"),
 		key.WithHelp("g", "new group"),
 	),
+	SubGrp: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("G", "new subgroup"),
+	),
 	Delete: key.NewBinding(
 		key.WithKeys("d"),
 		key.WithHelp("d", "delete"),
@@ -61,6 +119,118 @@ var keys = keyMap{
 		key.WithKeys("f"),
 		key.WithHelp("f", "full tmux"),
 	),
+	Pin: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pin/unpin"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark/unmark"),
+	),
+	Tags: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "edit tags"),
+	),
+	Broadcast: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "broadcast prompt"),
+	),
+	Compose: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "compose prompt"),
+	),
+	Snippets: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "send snippet"),
+	),
+	Template: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "save as template"),
+	),
+	FromTmpl: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "new from template"),
+	),
+	Clone: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "clone session"),
+	),
+	LaunchCfg: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "claude args/env"),
+	),
+	DelTmpl: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "delete template"),
+	),
+	Stop: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "stop session / group"),
+	),
+	Restart: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "restart session"),
+	),
+	Launch: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "launch group"),
+	),
+	Orphans: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "orphaned tmux sessions"),
+	),
+	Adopt: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "adopt orphan"),
+	),
+	Dashboard: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "dashboard"),
+	),
+	Diff: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "view diff"),
+	),
+	Stats: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "activity stats"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort mode"),
+	),
+	Flat: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "all sessions / grouped"),
+	),
+	Jump: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "switch to pinned session"),
+	),
+	Archive: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "archive / restore"),
+	),
+	Archived: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "show archived"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search sessions"),
+	),
+	StageHunk: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "stage hunk"),
+	),
+	RevertHunk: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "revert hunk"),
+	),
+	ReloadDiff: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "reload diff"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
@@ -81,10 +251,73 @@ var keys = keyMap{
 		key.WithKeys("n"),
 		key.WithHelp("n", "no"),
 	),
+	StopToo: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "delete & stop tmux"),
+	),
+	Worktree: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "delete & remove worktree"),
+	),
+	ExitLive: key.NewBinding(
+		key.WithKeys("ctrl+q"),
+		key.WithHelp("ctrl+q", "exit LIVE mode"),
+	),
+	NextField: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next field"),
+	),
+	UseRoot: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "use git repo root"),
+	),
+	PrevField: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous field"),
+	),
+	Send: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "send prompt"),
+	),
+	HistoryPrev: key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "older prompt"),
+	),
+	HistoryNext: key.NewBinding(
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdn", "newer prompt"),
+	),
+}
+
+// ShortHelp implements help.KeyMap.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Tab, k.Enter, k.Interact, k.Help, k.Quit}
+}
+
+// FullHelp implements help.KeyMap.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Tab, k.Enter, k.Interact},
+		{k.NewGrp, k.SubGrp, k.NewSess, k.FromTmpl, k.Clone, k.Template, k.LaunchCfg, k.Delete, k.Rename, k.Stop, k.Restart, k.Launch, k.Orphans, k.Pin, k.Jump, k.Mark, k.Tags, k.Compose, k.Snippets, k.Broadcast, k.Dashboard, k.Diff, k.Stats, k.Sort, k.Flat, k.Archive, k.Archived, k.Search, k.Help, k.Quit},
+	}
+}
+
+// withHelp returns a copy of b with a different help description, for
+// bindings whose meaning depends on the focused panel or mode.
+func withHelp(b key.Binding, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(b.Keys()...), key.WithHelp(b.Help().Key, desc))
 }
 
 func normalHelpText() string {
-	return " ↑↓ Navigate  Tab Switch Panel  ↵ Expand/Attach  i Interact  n New  g Group  d Del  r Rename  q Quit"
+	return " ↑↓ Navigate  Tab Switch Panel  ↵ Expand/Attach  i Interact  n New  g Group  d Del  r Rename  ? Help  q Quit"
+}
+
+func diffHelpText() string {
+	return " ± DIFF  ←→ File  ↑↓ Hunk  s Stage  r Revert  g Reload  esc Close"
+}
+
+func dashboardHelpText() string {
+	return " ▦ DASHBOARD  ←↑↓→ Move  i LIVE  ↵ Attach  Tab Group/Pinned  p Pin  esc Close"
 }
 
 func interactHelpText() string {
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// launchProgressMsg reports one finished launch; done is set once the last
+// result has been delivered.
+type launchProgressMsg struct {
+	result lifecycleResult
+	done   bool
+}
+
+// launchState tracks a running group launch.
+type launchState struct {
+	group   string
+	total   int
+	skipped int // already running
+	results []lifecycleResult
+	ch      <-chan launchProgressMsg
+}
+
+// launchGroup starts every stopped session of the selected group and of
+// the groups nested in it in the background, at most cfg.LaunchLimit() at a
+// time.
+func (m Model) launchGroup() (tea.Model, tea.Cmd) {
+	groups := m.store.Groups()
+	if m.groupIdx >= len(groups) {
+		return m, nil
+	}
+	if m.launch != nil {
+		m.statusMsg = fmt.Sprintf("Already launching %s", m.launch.group)
+		return m, nil
+	}
+	var jobs []lifecycleJob
+	skipped := 0
+	for _, p := range m.subtreeSessions(m.groupIdx) {
+		j := m.jobAt(p.groupIdx, p.sessionIdx)
+		if m.tmuxSessions[j.tmuxName] {
+			skipped++
+			continue
+		}
+		m.recordBaseline(p.groupIdx, p.sessionIdx)
+		m.recordActivityAt(p.groupIdx, p.sessionIdx, model.EventLaunch)
+		jobs = append(jobs, j)
+	}
+	if len(jobs) == 0 {
+		m.statusMsg = "All sessions in this group are already running"
+		return m, nil
+	}
+
+	ch := make(chan launchProgressMsg)
+	m.launch = &launchState{group: groups[m.groupIdx].Name, total: len(jobs), skipped: skipped, ch: ch}
+	m.statusMsg = fmt.Sprintf("Launching %s: 0/%d", m.launch.group, len(jobs))
+	return m, tea.Batch(runLaunch(jobs, m.cfg.LaunchLimit(), ch), waitLaunch(ch))
+}
+
+// runLaunch creates the tmux sessions with bounded concurrency and streams
+// results to ch, closing it when all are done.
+func runLaunch(jobs []lifecycleJob, limit int, ch chan<- launchProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		sem := make(chan struct{}, limit)
+		var wg sync.WaitGroup
+		for _, j := range jobs {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				err := tmux.NewSession(j.tmuxName, j.path, j.launch)
+				ch <- launchProgressMsg{result: lifecycleResult{job: j, err: err}}
+			}()
+		}
+		wg.Wait()
+		close(ch)
+		return nil
+	}
+}
+
+// waitLaunch delivers the next launch result.
+func waitLaunch(ch <-chan launchProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return launchProgressMsg{done: true}
+		}
+		return msg
+	}
+}
+
+func (m Model) handleLaunchProgress(msg launchProgressMsg) (tea.Model, tea.Cmd) {
+	l := m.launch
+	if l == nil {
+		return m, nil
+	}
+	if !msg.done {
+		l.results = append(l.results, msg.result)
+		m.statusMsg = fmt.Sprintf("Launching %s: %d/%d", l.group, len(l.results), l.total)
+		return m, waitLaunch(l.ch)
+	}
+
+	m.launch = nil
+	var failed []string
+	for _, r := range l.results {
+		if r.err != nil {
+			failed = append(failed, r.job.name)
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Launched %d/%d in %s", l.total-len(failed), l.total, l.group)
+	if l.skipped > 0 {
+		m.statusMsg += fmt.Sprintf(" (%d already running)", l.skipped)
+	}
+	if len(failed) > 0 {
+		m.statusMsg += " • failed: " + strings.Join(failed, ", ")
+		m.launchReport = l
+		m.dialog = dialogLaunchResult
+	}
+	return m, nil
+}
+
+func (m Model) renderLaunchResult() string {
+	l := m.launchReport
+	title := dialogTitleStyle.Render("▶ Launch " + l.group)
+	var lines []string
+	for _, r := range l.results {
+		if r.err == nil {
+			lines = append(lines, "  "+statusRunning.Render("✓")+" "+metaValueStyle.Render(r.job.name))
+			continue
+		}
+		lines = append(lines, "  "+statusStopped.Render("✗")+" "+metaValueStyle.Render(r.job.name))
+		lines = append(lines, dimStyle.PaddingLeft(6).Width(50).Render(r.err.Error()))
+	}
+	hint := dimStyle.Render("↵/esc close")
+	return dialogStyle.Render(title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + hint)
+}
@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type lifecycleAction int
+
+const (
+	actionStop lifecycleAction = iota
+	actionRestart
+)
+
+// lifecycleJob is one session to stop or restart.
+type lifecycleJob struct {
+	name     string // display name
+	tmuxName string
+	path     string
+	launch   tmux.Launch
+}
+
+type lifecycleResult struct {
+	job      lifecycleJob
+	graceful bool
+	err      error
+}
+
+type lifecycleDoneMsg struct {
+	action  lifecycleAction
+	results []lifecycleResult
+}
+
+// jobAt builds a lifecycle job for the session at (gi, si).
+func (m Model) jobAt(gi, si int) lifecycleJob {
+	g := m.store.Groups()[gi]
+	s := g.Sessions[si]
+	return lifecycleJob{
+		name:     s.Name,
+		tmuxName: tmux.SanitizeName(m.store.GroupPath(gi), s.Name),
+		path:     model.ExpandPath(s.Path),
+		launch:   launchFor(s),
+	}
+}
+
+// runningJobsInGroup returns jobs for the sessions of group gi and its
+// subgroups that have a live tmux session.
+func (m Model) runningJobsInGroup(gi int) []lifecycleJob {
+	var jobs []lifecycleJob
+	for _, g := range m.store.Subtree(gi) {
+		for si := range m.store.Sessions(g) {
+			if j := m.jobAt(g, si); m.tmuxSessions[j.tmuxName] {
+				jobs = append(jobs, j)
+			}
+		}
+	}
+	return jobs
+}
+
+// lifecycleCmd stops (and for restarts, relaunches) every job concurrently.
+func (m Model) lifecycleCmd(action lifecycleAction, jobs []lifecycleJob) tea.Cmd {
+	timeout := m.cfg.StopTimeout()
+	return func() tea.Msg {
+		results := make([]lifecycleResult, len(jobs))
+		var wg sync.WaitGroup
+		for i, j := range jobs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r := lifecycleResult{job: j}
+				r.graceful, r.err = tmux.StopSession(j.tmuxName, timeout)
+				if r.err == nil && action == actionRestart {
+					r.err = tmux.NewSession(j.tmuxName, j.path, j.launch)
+				}
+				results[i] = r
+			}()
+		}
+		wg.Wait()
+		return lifecycleDoneMsg{action: action, results: results}
+	}
+}
+
+// startLifecycle sets a progress status and returns the command for jobs.
+func (m Model) startLifecycle(action lifecycleAction, jobs []lifecycleJob) (tea.Model, tea.Cmd) {
+	if len(jobs) == 0 {
+		m.statusMsg = "Nothing to stop"
+		return m, nil
+	}
+	verb := "Stopping"
+	if action == actionRestart {
+		verb = "Restarting"
+	}
+	if len(jobs) == 1 {
+		m.statusMsg = fmt.Sprintf("%s %s...", verb, jobs[0].name)
+	} else {
+		m.statusMsg = fmt.Sprintf("%s %d sessions...", verb, len(jobs))
+	}
+	return m, m.lifecycleCmd(action, jobs)
+}
+
+func (m Model) handleLifecycleDone(msg lifecycleDoneMsg) (tea.Model, tea.Cmd) {
+	var ok, killed, failed []string
+	for _, r := range msg.results {
+		switch {
+		case r.err != nil:
+			failed = append(failed, fmt.Sprintf("%s (%v)", r.job.name, r.err))
+		case !r.graceful:
+			killed = append(killed, r.job.name)
+		default:
+			ok = append(ok, r.job.name)
+		}
+	}
+
+	verb := "Stopped"
+	if msg.action == actionRestart {
+		verb = "Restarted"
+	}
+	var parts []string
+	if len(ok) > 0 {
+		parts = append(parts, fmt.Sprintf("%s %s", verb, strings.Join(ok, ", ")))
+	}
+	if len(killed) > 0 {
+		parts = append(parts, fmt.Sprintf("killed after timeout: %s", strings.Join(killed, ", ")))
+	}
+	if len(failed) > 0 {
+		parts = append(parts, fmt.Sprintf("failed: %s", strings.Join(failed, "; ")))
+	}
+	m.statusMsg = strings.Join(parts, " • ")
+	return m, nil
+}
+
+// stopSelected stops the selected session, or on a group header asks to
+// stop every running session in the group.
+func (m Model) stopSelected() (tea.Model, tea.Cmd) {
+	if len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	if m.onGroupHeader() {
+		if len(m.runningJobsInGroup(m.groupIdx)) == 0 {
+			m.statusMsg = "No running sessions in this group"
+			return m, nil
+		}
+		m.dialog = dialogStopGroupConfirm
+		return m, nil
+	}
+	j := m.jobAt(m.groupIdx, m.sessionIdx)
+	if !m.tmuxSessions[j.tmuxName] {
+		m.statusMsg = "Session not running"
+		return m, nil
+	}
+	return m.startLifecycle(actionStop, []lifecycleJob{j})
+}
+
+// restartSelected stops the selected session if running and launches it
+// again in the background.
+func (m Model) restartSelected() (tea.Model, tea.Cmd) {
+	if m.onGroupHeader() || len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	if m.selectedArchived() {
+		m.statusMsg = "Session is archived. Press z to restore it."
+		return m, nil
+	}
+	m.recordBaseline(m.groupIdx, m.sessionIdx)
+	m.recordActivityAt(m.groupIdx, m.sessionIdx, model.EventLaunch)
+	return m.startLifecycle(actionRestart, []lifecycleJob{m.jobAt(m.groupIdx, m.sessionIdx)})
+}
+
+func (m Model) renderStopGroupDialog() string {
+	group := m.store.Groups()[m.groupIdx]
+	title := dialogTitleStyle.Render("⏹ Stop Group")
+	var names []string
+	for _, j := range m.runningJobsInGroup(m.groupIdx) {
+		names = append(names, "  "+statusRunning.Render("●")+" "+metaValueStyle.Render(j.name))
+	}
+	msg := metaValueStyle.Render("Stop all running sessions in ") + metaNameStyle.Render(group.Name) +
+		metaValueStyle.Render(" ?")
+	hint := dimStyle.Render("Claude is asked to /exit first, then killed after the timeout") + "\n" +
+		dimStyle.Render("y yes  n/esc no")
+	return dialogStyle.Render(title + "\n\n" + msg + "\n" + strings.Join(names, "\n") + "\n\n" + hint)
+}
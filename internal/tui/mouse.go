@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// treeRowOffset is the screen row of the first tree item: header bar,
+	// panel border, panel title and its bottom margin.
+	treeRowOffset = 4
+
+	doubleClickInterval = 400 * time.Millisecond
+	wheelStep           = 3
+
+	minTreeWidth    = 20
+	minPreviewWidth = 30
+)
+
+// updateMouse handles clicks, wheel scrolling and divider dragging.
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	leftWidth, _, contentHeight := m.layout()
+	treeRight := leftWidth + 2 // left panel width including its border
+	onDivider := msg.X == treeRight-1 || msg.X == treeRight
+	inPanels := msg.Y >= 1 && msg.Y < contentHeight+3
+
+	if m.dragging {
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			m.leftWidth = min(max(msg.X-1, minTreeWidth), max(m.width-minPreviewWidth-4, minTreeWidth))
+		case tea.MouseActionRelease:
+			m.dragging = false
+		}
+		return m, nil
+	}
+
+	if !inPanels {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		delta := 1
+		if msg.Button == tea.MouseButtonWheelUp {
+			delta = -1
+		}
+		if msg.X < treeRight {
+			if !m.interactMode {
+				m.moveTree(delta)
+			}
+			return m, nil
+		}
+		m.scrollPreview(-delta * wheelStep)
+		return m, nil
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		if onDivider {
+			m.dragging = true
+			return m, nil
+		}
+		if msg.X < treeRight {
+			if m.interactMode {
+				return m, nil
+			}
+			return m.clickTree(msg.Y)
+		}
+		if !m.interactMode {
+			m.focus = panelPreview
+		}
+	}
+	return m, nil
+}
+
+// clickTree selects the tree row under screen row y. A second click on the
+// same row within doubleClickInterval launches/attaches the session or
+// toggles the group.
+func (m Model) clickTree(y int) (tea.Model, tea.Cmd) {
+	tree := m.buildTree()
+	row := y - treeRowOffset
+	if len(m.pinnedOrder()) > 0 {
+		// The Pinned section header is not a tree position.
+		if row == 0 {
+			return m, nil
+		}
+		row--
+	}
+	if hr := m.archivedHeaderRow(); hr >= 0 && row >= hr {
+		if row == hr {
+			return m.toggleArchived()
+		}
+		row--
+	}
+	if row < 0 || row >= len(tree) {
+		return m, nil
+	}
+	now := time.Now()
+	double := y == m.lastClickY && now.Sub(m.lastClick) < doubleClickInterval
+	m.lastClick, m.lastClickY = now, y
+	if double {
+		// Prevent a third click from counting as another double-click.
+		m.lastClick = time.Time{}
+	}
+
+	pos := tree[row]
+	if pos.groupIdx != m.groupIdx || pos.sessionIdx != m.sessionIdx {
+		m.previewScroll = 0
+	}
+	m.selectTreeIdx(tree, row)
+	m.focus = panelTree
+
+	if !double {
+		return m, nil
+	}
+	if m.onGroupHeader() {
+		m.expanded[m.groupIdx] = !m.expanded[m.groupIdx]
+		return m, nil
+	}
+	return m.attachSession()
+}
+
+// scrollPreview moves the preview history by delta lines (positive = older).
+func (m *Model) scrollPreview(delta int) {
+	total := strings.Count(strings.TrimRight(m.previewContent, "\n"), "\n") + 1
+	m.previewScroll = min(max(m.previewScroll+delta, 0), total)
+}
@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// findOrphans returns the claude_* tmux sessions that match no stored
+// session, e.g. after a rename, a delete or a data file reset.
+func (m Model) findOrphans() ([]tmux.PaneInfo, error) {
+	panes, err := tmux.ListPanes()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for gi, g := range m.store.Groups() {
+		for si := range g.Sessions {
+			known[m.tmuxNameAt(treePos{gi, si})] = true
+		}
+	}
+	var out []tmux.PaneInfo
+	for _, p := range panes {
+		if strings.HasPrefix(p.Session, tmux.SessionPrefix) && !known[p.Session] {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// openOrphans shows the reconcile dialog.
+func (m Model) openOrphans() (tea.Model, tea.Cmd) {
+	orphans, err := m.findOrphans()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	if len(orphans) == 0 {
+		m.statusMsg = "No orphaned tmux sessions"
+		return m, nil
+	}
+	m.orphans = orphans
+	m.orphanIdx = 0
+	m.orphanKill = false
+	m.dialog = dialogOrphans
+	return m, nil
+}
+
+// reloadOrphans refreshes the list after a kill or adopt, closing the
+// dialog once nothing is left.
+func (m *Model) reloadOrphans() {
+	orphans, err := m.findOrphans()
+	if err != nil {
+		m.err = err
+	}
+	m.orphans = orphans
+	m.orphanIdx = min(m.orphanIdx, max(len(orphans)-1, 0))
+	if len(orphans) == 0 {
+		m.dialog = dialogNone
+	}
+}
+
+func (m Model) updateOrphans(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.orphanKill {
+		m.orphanKill = false
+		if key.Matches(msg, keys.Yes) {
+			name := m.orphans[m.orphanIdx].Session
+			if err := tmux.KillSession(name); err != nil {
+				m.statusMsg = fmt.Sprintf("Error: %v", err)
+				return m, nil
+			}
+			m.statusMsg = fmt.Sprintf("Killed %s", name)
+			m.reloadOrphans()
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.dialog = dialogNone
+		return m, nil
+	case key.Matches(msg, keys.Up):
+		m.orphanIdx = max(m.orphanIdx-1, 0)
+	case key.Matches(msg, keys.Down):
+		m.orphanIdx = min(m.orphanIdx+1, len(m.orphans)-1)
+	case key.Matches(msg, keys.Stop):
+		m.orphanKill = true
+	case key.Matches(msg, keys.Adopt):
+		return m.openAdopt(m.orphans[m.orphanIdx])
+	case msg.Type == tea.KeyEnter:
+		m.dialog = dialogNone
+		return m, tea.ExecProcess(tmux.AttachCmd(m.orphans[m.orphanIdx].Session), func(err error) tea.Msg {
+			return tmuxExitMsg{err: err}
+		})
+	}
+	return m, nil
+}
+
+// openAdopt asks where to file an orphan. The group and name are guessed
+// from the tmux session name; path and session ID come from the pane.
+func (m Model) openAdopt(p tmux.PaneInfo) (tea.Model, tea.Cmd) {
+	group, name := "", strings.TrimPrefix(p.Session, tmux.SessionPrefix)
+	for gi := range m.store.Groups() {
+		path := m.store.GroupPath(gi)
+		if rest, ok := strings.CutPrefix(p.Session, tmux.SanitizeName(path, "")+"_"); ok && len(path) > len(group) {
+			group, name = path, rest
+		}
+	}
+	if group == "" && m.groupIdx < len(m.store.Groups()) {
+		group = m.store.GroupPath(m.groupIdx)
+	}
+
+	m.orphanAdopt = p.Session
+	m.dialog = dialogAdoptOrphan
+	m.inputs = []textinput.Model{
+		newInput("Group", "existing or new group", 50),
+		newInput("Name", "display name", 50),
+		newInput("Path", "/path/to/project", 50),
+		newInput("Session ID", "claude session id", 50),
+	}
+	m.inputs[0].SetValue(group)
+	m.inputs[1].SetValue(name)
+	m.inputs[2].SetValue(p.Path)
+	m.inputs[3].SetValue(p.ClaudeSessionID())
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+// submitAdopt stores the orphan as a session and renames its tmux session
+// so the tree tracks it from now on.
+func (m Model) submitAdopt() (tea.Model, tea.Cmd) {
+	groupName := strings.TrimSpace(m.inputs[0].Value())
+	name := strings.TrimSpace(m.inputs[1].Value())
+	path := strings.TrimSpace(m.inputs[2].Value())
+	sessionID := strings.TrimSpace(m.inputs[3].Value())
+	if groupName == "" || name == "" || path == "" || sessionID == "" {
+		m.statusMsg = "All fields are required"
+		return m, nil
+	}
+
+	tmuxName := tmux.SanitizeName(model.CleanGroupPath(groupName), name)
+	if tmuxName != m.orphanAdopt {
+		if tmux.SessionExists(tmuxName) {
+			m.statusMsg = fmt.Sprintf("tmux session %s already exists", tmuxName)
+			return m, nil
+		}
+		if err := tmux.RenameSession(m.orphanAdopt, tmuxName); err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+	}
+
+	gi := m.store.EnsureGroupPath(groupName)
+	si := m.store.AddSession(gi, name, sessionID, path)
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.tmuxSessions[tmuxName] = true
+	m.groupIdx, m.sessionIdx = gi, si
+	m.expanded[gi] = true
+	m.statusMsg = fmt.Sprintf("Adopted %s into %s", name, groupName)
+
+	m.inputs = nil
+	m.dialog = dialogOrphans
+	m.reloadOrphans()
+	return m, nil
+}
+
+func (m Model) renderOrphansDialog() string {
+	if m.dialog == dialogAdoptOrphan {
+		title := dialogTitleStyle.Render("⇲ Adopt " + m.orphanAdopt)
+		labels := []string{"Group:", "Name:", "Path:", "Session ID:"}
+		var fields []string
+		for i, l := range labels {
+			fields = append(fields, dialogLabelStyle.Render(l)+"\n"+m.inputs[i].View())
+		}
+		hint := dimStyle.Render("tab next field  ↵ adopt  esc cancel")
+		return dialogStyle.Render(title + "\n\n" + strings.Join(fields, "\n\n") + "\n\n" + hint)
+	}
+
+	title := dialogTitleStyle.Render(fmt.Sprintf("⚠ Orphaned tmux sessions (%d)", len(m.orphans)))
+	var rows []string
+	for i, p := range m.orphans {
+		name := p.Session
+		if i == m.orphanIdx {
+			name = selectArrowStyle.Render("› ") + metaNameStyle.Render(name)
+		} else {
+			name = "  " + metaValueStyle.Render(name)
+		}
+		rows = append(rows, name, "    "+dimStyle.Render(truncate(p.Command+"  "+p.Path, 50)))
+	}
+	hint := dimStyle.Render("↑↓ select  ↵ attach  a adopt  x kill  esc close")
+	if m.orphanKill {
+		hint = errorStyle.Render(fmt.Sprintf("Kill %s? y/n", m.orphans[m.orphanIdx].Session))
+	}
+	return dialogStyle.Render(title + "\n\n" + strings.Join(rows, "\n") + "\n\n" + hint)
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"claude-session-manager/internal/git"
+	"claude-session-manager/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxPathCompletions is the number of candidate directories listed under
+// the path field when Tab is ambiguous.
+const maxPathCompletions = 6
+
+// pathCheck is the live validation state of the new-session path field.
+type pathCheck struct {
+	raw      string
+	expanded string
+	exists   bool
+	isDir    bool
+	gitRoot  string // enclosing repository root, "" outside git
+}
+
+// dir returns the cleaned expanded path, the key of Model.rootCache.
+func (c pathCheck) dir() string {
+	if c.expanded == "" {
+		return ""
+	}
+	return filepath.Clean(c.expanded)
+}
+
+// gitRootMsg carries the repository root of a directory typed in the path
+// field; root is "" outside git.
+type gitRootMsg struct {
+	dir  string
+	root string
+}
+
+// checkPath validates raw with a stat only; the repository root is looked
+// up in the background by lookupGitRoot.
+func checkPath(raw string) pathCheck {
+	c := pathCheck{raw: raw, expanded: model.ExpandPath(strings.TrimSpace(raw))}
+	if c.expanded == "" {
+		return c
+	}
+	info, err := os.Stat(c.expanded)
+	if err != nil {
+		return c
+	}
+	c.exists = true
+	c.isDir = info.IsDir()
+	return c
+}
+
+// updatePathCheck re-validates the path field if its value changed, taking
+// the repository root from the cache when it is known.
+func (m *Model) updatePathCheck() {
+	if m.dialog != dialogNewSession || len(m.inputs) == 0 {
+		return
+	}
+	if v := m.inputs[0].Value(); v != m.pathCheck.raw {
+		m.pathCheck = checkPath(v)
+		m.pathCheck.gitRoot = m.rootCache[m.pathCheck.dir()]
+		m.pathCompletions = nil
+	}
+}
+
+// lookupGitRoot starts a background lookup of the repository root of the
+// path field, unless it is not a directory or its root is cached or being
+// looked up already.
+func (m *Model) lookupGitRoot() tea.Cmd {
+	dir := m.pathCheck.dir()
+	if m.dialog != dialogNewSession || !m.pathCheck.isDir || m.rootPending[dir] {
+		return nil
+	}
+	if _, ok := m.rootCache[dir]; ok {
+		return nil
+	}
+	m.rootPending[dir] = true
+	return func() tea.Msg {
+		root, err := git.RepoRoot(dir)
+		if err != nil {
+			root = ""
+		}
+		return gitRootMsg{dir: dir, root: root}
+	}
+}
+
+func (m Model) handleGitRoot(msg gitRootMsg) (tea.Model, tea.Cmd) {
+	m.rootCache[msg.dir] = msg.root
+	delete(m.rootPending, msg.dir)
+	if m.pathCheck.dir() == msg.dir {
+		m.pathCheck.gitRoot = msg.root
+	}
+	return m, nil
+}
+
+// completePath handles Tab in the path field. It extends the value to the
+// longest common prefix of matching subdirectories and lists them when that
+// is ambiguous. It returns false when there is nothing to complete, so Tab
+// moves to the next field instead.
+func (m *Model) completePath() bool {
+	raw := m.inputs[0].Value()
+	if raw == "" || (m.pathCheck.isDir && strings.HasSuffix(raw, "/")) {
+		return false
+	}
+	if m.pathCheck.isDir {
+		m.inputs[0].SetValue(raw + "/")
+		m.inputs[0].CursorEnd()
+		m.updatePathCheck()
+		return true
+	}
+
+	dir, base := filepath.Split(raw)
+	if dir == "" {
+		return false
+	}
+	entries, err := os.ReadDir(model.ExpandPath(dir))
+	if err != nil {
+		return false
+	}
+	var matches []string
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), base) {
+			continue
+		}
+		if strings.HasPrefix(e.Name(), ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		matches = append(matches, e.Name())
+	}
+	if len(matches) == 0 {
+		return false
+	}
+	sort.Strings(matches)
+
+	prefix := matches[0]
+	for _, name := range matches[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(matches) == 1 {
+		prefix += "/"
+	}
+	m.inputs[0].SetValue(dir + prefix)
+	m.inputs[0].CursorEnd()
+	m.updatePathCheck()
+	if len(matches) > 1 {
+		m.pathCompletions = matches
+	}
+	return true
+}
+
+// useGitRoot replaces the path with the enclosing repository root.
+func (m *Model) useGitRoot() {
+	if m.pathCheck.gitRoot == "" {
+		return
+	}
+	m.inputs[0].SetValue(m.pathCheck.gitRoot)
+	m.inputs[0].CursorEnd()
+	m.updatePathCheck()
+}
+
+// renderPathHint shows the expanded path, whether it exists, and the git
+// root when the path is below it.
+func (m Model) renderPathHint() string {
+	c := m.pathCheck
+	if strings.TrimSpace(c.raw) == "" {
+		return ""
+	}
+	var lines []string
+	if c.expanded != strings.TrimSpace(c.raw) {
+		lines = append(lines, dimStyle.Render("→ "+truncate(c.expanded, 56)))
+	}
+	switch {
+	case !c.exists:
+		lines = append(lines, statusStopped.Render("✗ no such directory"))
+	case !c.isDir:
+		lines = append(lines, statusStopped.Render("✗ not a directory"))
+	case c.gitRoot != "" && filepath.Clean(c.gitRoot) != filepath.Clean(c.expanded):
+		lines = append(lines, statusRunning.Render("✓ ")+dimStyle.Render("inside git repo "+truncate(c.gitRoot, 36)+"  ctrl+g use root"))
+	case c.gitRoot != "":
+		lines = append(lines, statusRunning.Render("✓ ")+dimStyle.Render("git repository root"))
+	default:
+		lines = append(lines, statusRunning.Render("✓ ")+dimStyle.Render("directory"))
+	}
+	if n := len(m.pathCompletions); n > 0 {
+		shown := m.pathCompletions[:min(n, maxPathCompletions)]
+		more := ""
+		if n > maxPathCompletions {
+			more = " …"
+		}
+		lines = append(lines, dimStyle.Render(truncate(strings.Join(shown, "/  ")+"/"+more, 56)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxQuickSwitch is the number of pinned sessions reachable with the
+// number keys.
+const maxQuickSwitch = 9
+
+// pinnedOrder returns the pinned sessions that are not archived, in the
+// order they were pinned. They head the tree in the Pinned section.
+func (m Model) pinnedOrder() []treePos {
+	groups := m.store.Groups()
+	var pinned []treePos
+	for gi, g := range groups {
+		for si, s := range g.Sessions {
+			if s.Pinned && !s.Archived {
+				pinned = append(pinned, treePos{gi, si})
+			}
+		}
+	}
+	sort.SliceStable(pinned, func(a, b int) bool {
+		sa := groups[pinned[a].groupIdx].Sessions[pinned[a].sessionIdx]
+		sb := groups[pinned[b].groupIdx].Sessions[pinned[b].sessionIdx]
+		return sa.PinnedAt.Before(sb.PinnedAt)
+	})
+	return pinned
+}
+
+// cursorInPinned reports whether the cursor is on the Pinned section entry
+// of the selected session.
+func (m Model) cursorInPinned() bool {
+	ss := m.store.Sessions(m.groupIdx)
+	if !m.inPinned || m.sessionIdx < 0 || m.sessionIdx >= len(ss) {
+		return false
+	}
+	return ss[m.sessionIdx].Pinned && !ss[m.sessionIdx].Archived
+}
+
+// quickSwitch selects the n-th pinned session (1-based) in the Pinned
+// section.
+func (m Model) quickSwitch(n int) (tea.Model, tea.Cmd) {
+	pinned := m.pinnedOrder()
+	if n < 1 || n > len(pinned) {
+		m.statusMsg = fmt.Sprintf("No pinned session %d (press p to pin one)", n)
+		return m, nil
+	}
+	p := pinned[n-1]
+	if p.groupIdx != m.groupIdx || p.sessionIdx != m.sessionIdx {
+		m.previewScroll = 0
+	}
+	m.groupIdx, m.sessionIdx = p.groupIdx, p.sessionIdx
+	m.inPinned = true
+	return m, nil
+}
+
+// renderPinnedRows renders the Pinned section header and its sessions, each
+// numbered for quick switching.
+func (m Model) renderPinnedRows(width int) []string {
+	pinned := m.pinnedOrder()
+	if len(pinned) == 0 {
+		return nil
+	}
+	groups := m.store.Groups()
+	lines := []string{itemStyle.Render(" " + selectArrowStyle.Render("★") + " " + groupNameStyle.Render("Pinned"))}
+	for i, p := range pinned {
+		s := groups[p.groupIdx].Sessions[p.sessionIdx]
+		path := m.store.GroupPath(p.groupIdx)
+		isRunning := m.tmuxSessions[m.tmuxNameAt(p)]
+		isSelected := m.cursorInPinned() && m.groupIdx == p.groupIdx && m.sessionIdx == p.sessionIdx
+
+		number := " "
+		if i < maxQuickSwitch {
+			number = fmt.Sprint(i + 1)
+		}
+		var statusDot string
+		switch {
+		case isSelected:
+			statusDot = statusRunning.Render("●")
+		case isRunning:
+			statusDot = dimStyle.Render("●")
+		default:
+			statusDot = statusStopped.Render("×")
+		}
+		name := truncate(s.Name, max(width-len(path)-16, 8))
+		body := fmt.Sprintf(" %s %s %s %s%s", treeConnectorStyle.Render(number), statusDot, name,
+			groupCountStyle.Render(path), m.sessionSuffix(p))
+
+		switch {
+		case isSelected && m.focus == panelTree && !m.interactMode:
+			lines = append(lines, selectedItemStyle.Width(width-2).Render(" ›"+body))
+		case isSelected:
+			lines = append(lines, selectedDimStyle.Render(" ›"+body))
+		default:
+			lines = append(lines, treeSessionStyle.Render("  "+body))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxSearchRows is the number of matches listed at once in the search.
+const maxSearchRows = 10
+
+// openSearch shows the session search, which covers archived sessions too.
+func (m Model) openSearch() (tea.Model, tea.Cmd) {
+	if len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	m.searchIdx = 0
+	m.dialog = dialogSearch
+	m.inputs = []textinput.Model{newInput("Search", "name, group, path, tag or session id", 40)}
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+// searchResults returns the sessions matching every word of the query in
+// their name, group path, project path, tags or Claude session ID. Archived
+// matches are listed after the others.
+func (m Model) searchResults() []treePos {
+	var words []string
+	if len(m.inputs) > 0 {
+		words = strings.Fields(strings.ToLower(m.inputs[0].Value()))
+	}
+	groups := m.store.Groups()
+	var out []treePos
+	for _, p := range append(m.sortedSessions(false), m.sortedSessions(true)...) {
+		s := groups[p.groupIdx].Sessions[p.sessionIdx]
+		text := strings.ToLower(strings.Join(append([]string{
+			s.Name, m.store.GroupPath(p.groupIdx), s.Path, s.SessionID,
+		}, s.Tags...), " "))
+		match := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := m.searchResults()
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.dialog = dialogNone
+		m.inputs = nil
+		return m, nil
+	case msg.Type == tea.KeyUp:
+		m.searchIdx = max(m.searchIdx-1, 0)
+		return m, nil
+	case msg.Type == tea.KeyDown:
+		m.searchIdx = min(m.searchIdx+1, max(len(list)-1, 0))
+		return m, nil
+	case msg.Type == tea.KeyEnter:
+		if m.searchIdx >= len(list) {
+			return m, nil
+		}
+		m.dialog = dialogNone
+		m.inputs = nil
+		m.selectSession(list[m.searchIdx])
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[0], cmd = m.inputs[0].Update(msg)
+	m.searchIdx = min(m.searchIdx, max(len(m.searchResults())-1, 0))
+	return m, cmd
+}
+
+// selectSession moves the cursor to the session at p, expanding its groups
+// or showing the Archived section so that it is visible.
+func (m *Model) selectSession(p treePos) {
+	if m.store.Groups()[p.groupIdx].Sessions[p.sessionIdx].Archived {
+		m.showArchived = true
+	} else {
+		m.expanded[p.groupIdx] = true
+		m.expandAncestors(p.groupIdx)
+	}
+	m.groupIdx, m.sessionIdx = p.groupIdx, p.sessionIdx
+	m.focus = panelTree
+	m.previewScroll = 0
+}
+
+func (m Model) renderSearchDialog() string {
+	title := dialogTitleStyle.Render("🔍 Search Sessions")
+	groups := m.store.Groups()
+	list := m.searchResults()
+	var rows []string
+	start := max(min(m.searchIdx-maxSearchRows/2, len(list)-maxSearchRows), 0)
+	for i := start; i < len(list) && i < start+maxSearchRows; i++ {
+		p := list[i]
+		s := groups[p.groupIdx].Sessions[p.sessionIdx]
+		dot := statusStopped.Render("×")
+		if m.tmuxSessions[m.tmuxNameAt(p)] {
+			dot = statusRunning.Render("●")
+		}
+		line := truncate(s.Name, 24)
+		extra := dimStyle.Render("  " + truncate(m.store.GroupPath(p.groupIdx), 20))
+		if s.Archived {
+			extra += statusWaiting.Render("  archived")
+		}
+		if i == m.searchIdx {
+			rows = append(rows, selectArrowStyle.Render("› ")+dot+" "+metaNameStyle.Render(line)+extra)
+		} else {
+			rows = append(rows, "  "+dot+" "+metaValueStyle.Render(line)+extra)
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, dimStyle.Render("  No matching sessions"))
+	}
+	hint := dimStyle.Render("↑↓ select  ↵ go to session  esc cancel")
+	return dialogStyle.Width(64).Render(title + "\n\n" + m.inputs[0].View() + "\n\n" + strings.Join(rows, "\n") + "\n\n" + hint)
+}
@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"claude-session-manager/internal/git"
+	"claude-session-manager/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxSnippetRows is the number of snippets listed at once in the picker.
+const maxSnippetRows = 10
+
+// snippetBranchMsg carries the git branch of a snippet's target session,
+// looked up after the placeholder dialog opens.
+type snippetBranchMsg struct {
+	target string
+	branch string
+}
+
+// openSnippets loads the snippet library and shows the picker for the
+// selected running session. The file is re-read each time so hand edits
+// apply without restarting.
+func (m Model) openSnippets() (tea.Model, tea.Cmd) {
+	tn := m.selectedTmuxName()
+	if tn == "" || !m.tmuxSessions[tn] {
+		m.statusMsg = "Session not running. Press Enter on tree to start."
+		return m, nil
+	}
+	lib, err := model.LoadSnippets()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Snippets: %v", err)
+		return m, nil
+	}
+	m.snippets = lib.ForGroup(m.store.GroupPath(m.groupIdx))
+	if len(m.snippets) == 0 {
+		m.statusMsg = fmt.Sprintf("No snippets defined in %s", lib.Path())
+		return m, nil
+	}
+	m.snippetIdx = 0
+	m.snippetTarget = tn
+	m.dialog = dialogSnippets
+	m.inputs = []textinput.Model{newInput("Filter", "type to filter", 40)}
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+// filteredSnippets returns the snippets whose name or description contains
+// the filter text.
+func (m Model) filteredSnippets() []model.Snippet {
+	filter := ""
+	if len(m.inputs) > 0 {
+		filter = strings.ToLower(strings.TrimSpace(m.inputs[0].Value()))
+	}
+	if filter == "" {
+		return m.snippets
+	}
+	var out []model.Snippet
+	for _, s := range m.snippets {
+		if strings.Contains(strings.ToLower(s.Name), filter) ||
+			strings.Contains(strings.ToLower(s.Description), filter) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (m Model) updateSnippets(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := m.filteredSnippets()
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.dialog = dialogNone
+		m.inputs = nil
+		return m, nil
+	case msg.Type == tea.KeyUp:
+		m.snippetIdx = max(m.snippetIdx-1, 0)
+		return m, nil
+	case msg.Type == tea.KeyDown:
+		m.snippetIdx = min(m.snippetIdx+1, max(len(list)-1, 0))
+		return m, nil
+	case msg.Type == tea.KeyEnter:
+		if m.snippetIdx >= len(list) {
+			return m, nil
+		}
+		return m.chooseSnippet(list[m.snippetIdx])
+	}
+
+	var cmd tea.Cmd
+	m.inputs[0], cmd = m.inputs[0].Update(msg)
+	m.snippetIdx = min(m.snippetIdx, max(len(m.filteredSnippets())-1, 0))
+	return m, cmd
+}
+
+// chooseSnippet sends a snippet without placeholders right away; otherwise
+// it asks for placeholder values, pre-filled from the session context.
+func (m Model) chooseSnippet(s model.Snippet) (tea.Model, tea.Cmd) {
+	m.snippetChosen = s
+	names := s.Placeholders()
+	if len(names) == 0 {
+		m.dialog = dialogNone
+		m.inputs = nil
+		m.statusMsg = fmt.Sprintf("Sending snippet %s...", s.Name)
+		return m, sendPromptCmd(m.snippetTarget, s.Text, s.Name)
+	}
+
+	defaults := m.snippetContext()
+	m.inputs = make([]textinput.Model, len(names))
+	for i, n := range names {
+		m.inputs[i] = newInput(n, n, 50)
+		m.inputs[i].SetValue(defaults[n])
+	}
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	m.dialog = dialogSnippetParams
+	if !slices.Contains(names, "branch") {
+		return m, textinput.Blink
+	}
+	return m, tea.Batch(textinput.Blink, snippetBranchCmd(m.snippetTarget, defaults["path"]))
+}
+
+// snippetContext returns built-in placeholder values for the selected
+// session. The branch comes later from snippetBranchCmd, as it runs git.
+func (m Model) snippetContext() map[string]string {
+	group := m.store.Groups()[m.groupIdx]
+	sess := group.Sessions[m.sessionIdx]
+	return map[string]string{
+		"name":       sess.Name,
+		"group":      group.Name,
+		"path":       model.ExpandPath(sess.Path),
+		"session_id": sess.SessionID,
+	}
+}
+
+func snippetBranchCmd(target, path string) tea.Cmd {
+	return func() tea.Msg {
+		branch, err := git.CurrentBranch(path)
+		if err != nil {
+			return nil
+		}
+		return snippetBranchMsg{target: target, branch: branch}
+	}
+}
+
+// handleSnippetBranch fills in the branch placeholder unless the dialog was
+// closed or the value was already typed.
+func (m Model) handleSnippetBranch(msg snippetBranchMsg) (tea.Model, tea.Cmd) {
+	if m.dialog != dialogSnippetParams || msg.target != m.snippetTarget {
+		return m, nil
+	}
+	for i, n := range m.snippetChosen.Placeholders() {
+		if n == "branch" && i < len(m.inputs) && m.inputs[i].Value() == "" {
+			m.inputs[i].SetValue(msg.branch)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) snippetValues() map[string]string {
+	values := make(map[string]string)
+	for i, n := range m.snippetChosen.Placeholders() {
+		if i < len(m.inputs) {
+			values[n] = m.inputs[i].Value()
+		}
+	}
+	return values
+}
+
+func (m Model) submitSnippetParams() (tea.Model, tea.Cmd) {
+	text := m.snippetChosen.Expand(m.snippetValues())
+	m.dialog = dialogNone
+	m.inputs = nil
+	m.statusMsg = fmt.Sprintf("Sending snippet %s...", m.snippetChosen.Name)
+	return m, sendPromptCmd(m.snippetTarget, text, m.snippetChosen.Name)
+}
+
+func (m Model) renderSnippetDialog() string {
+	switch m.dialog {
+	case dialogSnippets:
+		title := dialogTitleStyle.Render("✂ Snippets")
+		list := m.filteredSnippets()
+		var rows []string
+		start := max(min(m.snippetIdx-maxSnippetRows/2, len(list)-maxSnippetRows), 0)
+		for i := start; i < len(list) && i < start+maxSnippetRows; i++ {
+			s := list[i]
+			line := s.Name
+			if s.Description != "" {
+				line += dimStyle.Render("  " + truncate(s.Description, 30))
+			}
+			if i == m.snippetIdx {
+				rows = append(rows, selectArrowStyle.Render("› ")+metaNameStyle.Render(line))
+			} else {
+				rows = append(rows, "  "+metaValueStyle.Render(line))
+			}
+		}
+		if len(rows) == 0 {
+			rows = append(rows, dimStyle.Render("  No matching snippets"))
+		}
+		preview := ""
+		if m.snippetIdx < len(list) {
+			preview = "\n\n" + dimStyle.Render(truncate(strings.ReplaceAll(list[m.snippetIdx].Text, "\n", " ⏎ "), 48))
+		}
+		hint := dimStyle.Render("↑↓ select  ↵ send  esc cancel")
+		return dialogStyle.Render(title + "\n\n" + m.inputs[0].View() + "\n\n" + strings.Join(rows, "\n") + preview + "\n\n" + hint)
+
+	case dialogSnippetParams:
+		title := dialogTitleStyle.Render("✂ " + m.snippetChosen.Name)
+		var fields []string
+		for i, n := range m.snippetChosen.Placeholders() {
+			fields = append(fields, dialogLabelStyle.Render("{{"+n+"}}")+"\n"+m.inputs[i].View())
+		}
+		preview := dimStyle.Render(truncate(strings.ReplaceAll(m.snippetChosen.Expand(m.snippetValues()), "\n", " ⏎ "), 48))
+		hint := dimStyle.Render("tab next field  ↵ send  esc cancel")
+		return dialogStyle.Render(title + "\n\n" + strings.Join(fields, "\n\n") + "\n\n" + preview + "\n\n" + hint)
+	}
+	return ""
+}
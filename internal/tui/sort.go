@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortMode orders groups and sessions in the tree.
+type sortMode int
+
+const (
+	sortManual sortMode = iota
+	sortName
+	sortActivity
+	sortCreated
+	sortRunning
+	sortAttention
+)
+
+// sortModes names each mode as stored in state.json and as shown in the
+// status bar, in cycling order.
+var sortModes = []struct{ key, label string }{
+	sortManual:    {"manual", "manual order"},
+	sortName:      {"name", "name"},
+	sortActivity:  {"activity", "last activity"},
+	sortCreated:   {"created", "newest first"},
+	sortRunning:   {"running", "running first"},
+	sortAttention: {"attention", "needs attention first"},
+}
+
+// parseSortMode returns the mode stored under key, or sortManual.
+func parseSortMode(key string) sortMode {
+	for i, s := range sortModes {
+		if s.key == key {
+			return sortMode(i)
+		}
+	}
+	return sortManual
+}
+
+// lastOutputAt returns when the pane of the session at p last produced
+// output, or the zero time when it is not running.
+func (m Model) lastOutputAt(p treePos) time.Time {
+	return m.paneOutput[m.tmuxNameAt(p)]
+}
+
+// needsAttention reports whether a running session has finished producing
+// output that the user has not looked at: its pane has been idle for
+// activeIdle, and the output is newer than the last attach, interaction or
+// preview of it.
+func (m Model) needsAttention(p treePos) bool {
+	out := m.lastOutputAt(p)
+	if out.IsZero() || time.Since(out) < activeIdle {
+		return false
+	}
+	s := m.store.Groups()[p.groupIdx].Sessions[p.sessionIdx]
+	a := m.activity.For(s.ID)
+	seen := m.seen[s.ID]
+	for _, t := range []time.Time{a.LastAttached, a.LastInteracted} {
+		if t.After(seen) {
+			seen = t
+		}
+	}
+	return out.After(seen)
+}
+
+// sessionLess reports whether the session at a sorts before the one at b in
+// the current mode. Ties keep manual order, as the sorts are stable.
+func (m Model) sessionLess(a, b treePos) bool {
+	groups := m.store.Groups()
+	sa, sb := groups[a.groupIdx].Sessions[a.sessionIdx], groups[b.groupIdx].Sessions[b.sessionIdx]
+	rank := func(p treePos) int {
+		switch {
+		case m.sortMode == sortAttention && m.needsAttention(p):
+			return 0
+		case m.tmuxSessions[m.tmuxNameAt(p)]:
+			return 1
+		}
+		return 2
+	}
+	switch m.sortMode {
+	case sortName:
+		return strings.ToLower(sa.Name) < strings.ToLower(sb.Name)
+	case sortActivity:
+		return m.activity.For(sa.ID).LastUsed().After(m.activity.For(sb.ID).LastUsed())
+	case sortCreated:
+		return sa.CreatedAt.After(sb.CreatedAt)
+	case sortRunning, sortAttention:
+		return rank(a) < rank(b)
+	}
+	return false
+}
+
+// sessionOrder returns the indexes of group gi's sessions in display order,
+// leaving out archived ones.
+func (m Model) sessionOrder(gi int) []int {
+	var order []int
+	for si, s := range m.store.Sessions(gi) {
+		if !s.Archived {
+			order = append(order, si)
+		}
+	}
+	if m.sortMode != sortManual {
+		sort.SliceStable(order, func(a, b int) bool {
+			return m.sessionLess(treePos{gi, order[a]}, treePos{gi, order[b]})
+		})
+	}
+	return order
+}
+
+// subtreeSessions returns the sessions of group gi and of the groups nested
+// in it, each group in sessionOrder.
+func (m Model) subtreeSessions(gi int) []treePos {
+	var out []treePos
+	for _, g := range m.store.Subtree(gi) {
+		for _, si := range m.sessionOrder(g) {
+			out = append(out, treePos{g, si})
+		}
+	}
+	return out
+}
+
+// groupOrder returns the indexes of the groups directly inside parentID
+// ("" for the top level) in display order. Groups sort by their own name or
+// creation time, and otherwise by their first session in the current mode.
+func (m Model) groupOrder(parentID string) []int {
+	groups := m.store.Groups()
+	order := m.store.ChildGroups(parentID)
+	if m.sortMode == sortManual {
+		return order
+	}
+	first := make(map[int]treePos)
+	for _, gi := range order {
+		if so := m.sessionOrder(gi); len(so) > 0 {
+			first[gi] = treePos{gi, so[0]}
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ga, gb := groups[order[a]], groups[order[b]]
+		switch m.sortMode {
+		case sortName:
+			return strings.ToLower(ga.Name) < strings.ToLower(gb.Name)
+		case sortCreated:
+			return ga.CreatedAt.After(gb.CreatedAt)
+		}
+		fa, okA := first[order[a]]
+		fb, okB := first[order[b]]
+		if !okA || !okB {
+			return okA && !okB
+		}
+		return m.sessionLess(fa, fb)
+	})
+	return order
+}
+
+// flatOrder returns every session across groups in display order, for the
+// flat view.
+func (m Model) flatOrder() []treePos {
+	return m.sortedSessions(false)
+}
+
+// sortedSessions returns the sessions across groups that are, or are not,
+// archived, in display order.
+func (m Model) sortedSessions(archived bool) []treePos {
+	var all []treePos
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			if s.Archived == archived {
+				all = append(all, treePos{gi, si})
+			}
+		}
+	}
+	if m.sortMode == sortName {
+		// Sessions with the same name in different groups sort by group.
+		sort.SliceStable(all, func(a, b int) bool {
+			return strings.ToLower(m.store.GroupPath(all[a].groupIdx)) < strings.ToLower(m.store.GroupPath(all[b].groupIdx))
+		})
+	}
+	if m.sortMode != sortManual {
+		sort.SliceStable(all, func(a, b int) bool { return m.sessionLess(all[a], all[b]) })
+	}
+	return all
+}
+
+// cycleSort switches to the next sort mode and persists it.
+func (m Model) cycleSort() (tea.Model, tea.Cmd) {
+	m.sortMode = (m.sortMode + 1) % sortMode(len(sortModes))
+	m.uiState.Sort = sortModes[m.sortMode].key
+	if err := m.uiState.Save(); err != nil {
+		m.err = err
+	}
+	m.statusMsg = "Sort: " + sortModes[m.sortMode].label
+	return m, nil
+}
+
+// toggleFlat switches between the grouped tree and the flat list of all
+// sessions, and persists the choice.
+func (m Model) toggleFlat() (tea.Model, tea.Cmd) {
+	m.flat = !m.flat
+	m.uiState.View = ""
+	m.statusMsg = "Grouped view"
+	if m.flat {
+		m.uiState.View = "flat"
+		m.statusMsg = fmt.Sprintf("All sessions (%s)", sortModes[m.sortMode].label)
+		// The flat view has no group headers to rest on.
+		if m.onGroupHeader() {
+			if all := m.flatOrder(); len(all) > 0 {
+				m.groupIdx, m.sessionIdx = all[0].groupIdx, all[0].sessionIdx
+			}
+		}
+	}
+	if err := m.uiState.Save(); err != nil {
+		m.err = err
+	}
+	return m, nil
+}
+
+// markSeen records that the selected session's output was on screen.
+func (m *Model) markSeen() {
+	if m.sessionIdx < 0 || m.dialog != dialogNone {
+		return
+	}
+	p := treePos{m.groupIdx, m.sessionIdx}
+	if tn := m.tmuxNameAt(p); tn != "" && m.tmuxSessions[tn] {
+		m.seen[m.store.Groups()[p.groupIdx].Sessions[p.sessionIdx].ID] = time.Now()
+	}
+}
+
+// sortLabel is shown next to the tree title when the tree is not in manual
+// order.
+func (m Model) sortLabel() string {
+	if m.sortMode == sortManual {
+		return ""
+	}
+	return " · " + sortModes[m.sortMode].label
+}
+
+// renderFlatRows renders one row per session with its group path, for the
+// flat view and the Archived section.
+func (m Model) renderFlatRows(width int, order []treePos) []string {
+	groups := m.store.Groups()
+	var lines []string
+	for _, p := range order {
+		s := groups[p.groupIdx].Sessions[p.sessionIdx]
+		path := m.store.GroupPath(p.groupIdx)
+		isRunning := m.tmuxSessions[m.tmuxNameAt(p)]
+		isSelected := m.groupIdx == p.groupIdx && m.sessionIdx == p.sessionIdx && !m.cursorInPinned()
+
+		var statusDot string
+		switch {
+		case isSelected:
+			statusDot = statusRunning.Render("●")
+		case isRunning:
+			statusDot = dimStyle.Render("●")
+		default:
+			statusDot = statusStopped.Render("×")
+		}
+		name := truncate(s.Name, max(width-len(path)-14, 8))
+		body := fmt.Sprintf(" %s %s %s%s", statusDot, name, groupCountStyle.Render(path), m.sessionSuffix(p))
+
+		switch {
+		case isSelected && m.focus == panelTree && !m.interactMode:
+			lines = append(lines, selectedItemStyle.Width(width-2).Render(" ›"+body))
+		case isSelected:
+			lines = append(lines, selectedDimStyle.Render(" ›"+body))
+		default:
+			lines = append(lines, treeSessionStyle.Render("  "+body))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,95 @@
+package tui
+
+import "slices"
+
+// restoreUIState applies the saved expansion, cursor and focus. Groups and
+// sessions are matched by ID, so entries deleted since are skipped and the
+// cursor falls back to the group header or the first group.
+func (m *Model) restoreUIState() {
+	st := m.uiState
+	for gi, g := range m.store.Groups() {
+		m.expanded[gi] = !slices.Contains(st.Collapsed, g.ID)
+		if g.ID != st.Group {
+			continue
+		}
+		m.groupIdx = gi
+		m.expandAncestors(gi)
+		for si, s := range g.Sessions {
+			if s.ID == st.Session && st.Session != "" && !s.Archived {
+				m.sessionIdx = si
+				// A selected session is never hidden in a collapsed group.
+				m.expanded[gi] = true
+			}
+		}
+	}
+	if m.flat && m.onGroupHeader() {
+		if all := m.flatOrder(); len(all) > 0 {
+			m.groupIdx, m.sessionIdx = all[0].groupIdx, all[0].sessionIdx
+		}
+	}
+	if st.Focus == "preview" && !m.onGroupHeader() {
+		m.focus = panelPreview
+	}
+}
+
+// collapsedIDs returns the IDs of the collapsed groups in store order.
+func (m Model) collapsedIDs() []string {
+	var ids []string
+	for gi, g := range m.store.Groups() {
+		if !m.expanded[gi] {
+			ids = append(ids, g.ID)
+		}
+	}
+	return ids
+}
+
+// focusKey names the focused panel as stored in the state file.
+func (m Model) focusKey() string {
+	if m.focus == panelPreview {
+		return "preview"
+	}
+	return ""
+}
+
+// saveUIState records expansion, cursor and focus in the state file.
+func (m *Model) saveUIState() {
+	st := m.uiState
+	st.Collapsed = m.collapsedIDs()
+	st.Group, st.Session = "", ""
+	st.Focus = m.focusKey()
+	groups := m.store.Groups()
+	if m.groupIdx < len(groups) {
+		g := groups[m.groupIdx]
+		st.Group = g.ID
+		if m.sessionIdx >= 0 && m.sessionIdx < len(g.Sessions) {
+			st.Session = g.Sessions[m.sessionIdx].ID
+		}
+	}
+	if err := st.Save(); err != nil {
+		m.err = err
+	}
+}
+
+// saveLayout saves the UI state when expansion or focus changed since it
+// was last saved, so that they survive a crash or a killed terminal. Cursor
+// moves alone are saved on quit only.
+func (m Model) saveLayout() Model {
+	if m.focusKey() != m.uiState.Focus || !slices.Equal(m.collapsedIDs(), m.uiState.Collapsed) {
+		m.saveUIState()
+	}
+	return m
+}
+
+// shutdown persists what the TUI keeps in memory before it quits. Errors
+// are kept for QuitErr, as the TUI is gone by the time they could be shown.
+func (m *Model) shutdown() {
+	m.err = nil
+	m.flushActive()
+	m.saveUIState()
+	m.quitErr = m.err
+}
+
+// QuitErr returns the error, if any, of saving state when the TUI quit.
+func (m Model) QuitErr() error {
+	return m.quitErr
+}
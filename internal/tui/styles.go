@@ -2,198 +2,300 @@ package tui
 
 import "github.com/charmbracelet/lipgloss"
 
+// Palette and styles are package-level so rendering code can use them
+// directly; applyTheme rebuilds them all from a Theme.
 var (
 	// ── Color palette ─────────────────────────────────────────────────────
-	accentColor    = lipgloss.Color("#7C3AED")
-	accentDimColor = lipgloss.Color("#5B21B6")
-	dimColor       = lipgloss.Color("#6B7280")
-	textColor      = lipgloss.Color("#D1D5DB")
-	brightColor    = lipgloss.Color("#F3F4F6")
-	activeColor    = lipgloss.Color("#A78BFA")
-	successColor   = lipgloss.Color("#10B981")
-	warningColor   = lipgloss.Color("#F59E0B")
-	dangerColor    = lipgloss.Color("#EF4444")
-	infoColor      = lipgloss.Color("#3B82F6")
-	surfaceColor   = lipgloss.Color("#111827")
-	surface2Color  = lipgloss.Color("#1F2937")
-	borderColor    = lipgloss.Color("#374151")
-	borderDimColor = lipgloss.Color("#1F2937")
-	highlightBg    = lipgloss.Color("#312E81")
-	interactColor  = lipgloss.Color("#F59E0B")
+	accentColor    lipgloss.TerminalColor
+	accentDimColor lipgloss.TerminalColor
+	dimColor       lipgloss.TerminalColor
+	textColor      lipgloss.TerminalColor
+	brightColor    lipgloss.TerminalColor
+	activeColor    lipgloss.TerminalColor
+	successColor   lipgloss.TerminalColor
+	warningColor   lipgloss.TerminalColor
+	dangerColor    lipgloss.TerminalColor
+	infoColor      lipgloss.TerminalColor
+	surfaceColor   lipgloss.TerminalColor
+	surface2Color  lipgloss.TerminalColor
+	borderColor    lipgloss.TerminalColor
+	borderDimColor lipgloss.TerminalColor
+	highlightBg    lipgloss.TerminalColor
+	interactColor  lipgloss.TerminalColor
+	inputColor     lipgloss.TerminalColor
+
+	// ── Styles ────────────────────────────────────────────────────────────
+	titleStyle                lipgloss.Style
+	panelStyle                lipgloss.Style
+	panelActiveStyle          lipgloss.Style
+	panelInteractStyle        lipgloss.Style
+	panelTitleStyle           lipgloss.Style
+	panelTitleDimStyle        lipgloss.Style
+	groupNameStyle            lipgloss.Style
+	groupCountStyle           lipgloss.Style
+	selectedItemStyle         lipgloss.Style
+	selectedDimStyle          lipgloss.Style
+	selectArrowStyle          lipgloss.Style
+	itemStyle                 lipgloss.Style
+	treeSessionStyle          lipgloss.Style
+	treeConnectorStyle        lipgloss.Style
+	treeLabelStyle            lipgloss.Style
+	statusRunning             lipgloss.Style
+	statusStopped             lipgloss.Style
+	statusWaiting             lipgloss.Style
+	metaNameStyle             lipgloss.Style
+	metaLabelStyle            lipgloss.Style
+	metaValueStyle            lipgloss.Style
+	metaTagStyle              lipgloss.Style
+	metaGroupTagStyle         lipgloss.Style
+	metaConnectedStyle        lipgloss.Style
+	metaDisconnectedStyle     lipgloss.Style
+	metaSepStyle              lipgloss.Style
+	metaIconStyle             lipgloss.Style
+	previewTitleStyle         lipgloss.Style
+	previewInteractTitleStyle lipgloss.Style
+	previewTitleOfflineStyle  lipgloss.Style
+	previewContentStyle       lipgloss.Style
+	dimStyle                  lipgloss.Style
+	helpStyle                 lipgloss.Style
+	interactHelpStyle         lipgloss.Style
+	statusBarStyle            lipgloss.Style
+	errorStyle                lipgloss.Style
+	separatorStyle            lipgloss.Style
+	dialogStyle               lipgloss.Style
+	dialogTitleStyle          lipgloss.Style
+	dialogLabelStyle          lipgloss.Style
+	liveTagStyle              lipgloss.Style
+	diffAddStyle              lipgloss.Style
+	diffDelStyle              lipgloss.Style
+	diffHunkStyle             lipgloss.Style
+	diffMetaStyle             lipgloss.Style
+	synKeywordStyle           lipgloss.Style
+	synStringStyle            lipgloss.Style
+	synCommentStyle           lipgloss.Style
+)
+
+// applyTheme sets the palette from t and rebuilds every style.
+func applyTheme(t Theme) {
+	accentColor = t.Accent
+	accentDimColor = t.AccentDim
+	dimColor = t.Dim
+	textColor = t.Text
+	brightColor = t.Bright
+	activeColor = t.Active
+	successColor = t.Success
+	warningColor = t.Warning
+	dangerColor = t.Danger
+	infoColor = t.Info
+	surfaceColor = t.Surface
+	surface2Color = t.Surface2
+	borderColor = t.Border
+	borderDimColor = t.BorderDim
+	highlightBg = t.Highlight
+	interactColor = t.Warning
+	inputColor = t.Input
 
 	// ── Title bar ─────────────────────────────────────────────────────────
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(accentColor).
-			Padding(0, 1)
+		Bold(true).
+		Foreground(t.OnAccent).
+		Background(accentColor).
+		Reverse(t.Reverse).
+		Padding(0, 1)
 
 	// ── Panel frames ──────────────────────────────────────────────────────
 	panelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(borderDimColor).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderDimColor).
+		Padding(0, 1)
 
 	panelActiveStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(accentColor).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accentColor).
+		Padding(0, 1)
 
 	panelInteractStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(warningColor).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(warningColor).
+		Padding(0, 1)
 
 	// ── Panel titles ──────────────────────────────────────────────────────
 	panelTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(activeColor).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(activeColor).
+		MarginBottom(1)
 
 	panelTitleDimStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(dimColor).
-				MarginBottom(1)
+		Bold(true).
+		Foreground(dimColor).
+		MarginBottom(1)
 
 	// ── Tree items ────────────────────────────────────────────────────────
 	groupNameStyle = lipgloss.NewStyle().
-			Foreground(brightColor).
-			Bold(true)
+		Foreground(brightColor).
+		Bold(true)
 
 	groupCountStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(dimColor)
 
 	selectedItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(highlightBg).
-			Bold(true)
+		Foreground(t.OnHighlight).
+		Background(highlightBg).
+		Bold(true).
+		Reverse(t.Reverse)
 
 	selectedDimStyle = lipgloss.NewStyle().
-			Foreground(activeColor)
+		Foreground(activeColor)
 
 	selectArrowStyle = lipgloss.NewStyle().
-				Foreground(activeColor).
-				Bold(true)
+		Foreground(activeColor).
+		Bold(true)
 
 	itemStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			PaddingLeft(2)
+		Foreground(textColor).
+		PaddingLeft(2)
 
 	treeSessionStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#9CA3AF"))
+		Foreground(t.Muted)
 
 	treeConnectorStyle = lipgloss.NewStyle().
-				Foreground(borderColor)
+		Foreground(borderColor)
 
 	treeLabelStyle = lipgloss.NewStyle().
-			Foreground(dimColor).
-			Italic(true)
+		Foreground(dimColor).
+		Italic(true)
 
 	// ── Status indicators ─────────────────────────────────────────────────
 	statusRunning = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true)
+		Foreground(successColor).
+		Bold(true)
 
 	statusStopped = lipgloss.NewStyle().
-			Foreground(dangerColor)
+		Foreground(dangerColor)
 
 	statusWaiting = lipgloss.NewStyle().
-			Foreground(warningColor).
-			Bold(true)
+		Foreground(warningColor).
+		Bold(true)
 
 	// ── Preview metadata ──────────────────────────────────────────────────
 	metaNameStyle = lipgloss.NewStyle().
-			Foreground(brightColor).
-			Bold(true)
+		Foreground(brightColor).
+		Bold(true)
 
 	metaLabelStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(dimColor)
 
 	metaValueStyle = lipgloss.NewStyle().
-			Foreground(textColor)
+		Foreground(textColor)
 
 	metaTagStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(accentColor).
-			Padding(0, 1).
-			Bold(true)
+		Foreground(t.OnAccent).
+		Background(accentColor).
+		Padding(0, 1).
+		Bold(true)
 
 	metaGroupTagStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Background(infoColor).
-				Padding(0, 1)
+		Foreground(t.OnAccent).
+		Background(infoColor).
+		Padding(0, 1)
 
 	metaConnectedStyle = lipgloss.NewStyle().
-				Foreground(successColor).
-				Bold(true)
+		Foreground(successColor).
+		Bold(true)
 
 	metaDisconnectedStyle = lipgloss.NewStyle().
-				Foreground(dangerColor)
+		Foreground(dangerColor)
 
 	metaSepStyle = lipgloss.NewStyle().
-			Foreground(borderColor)
+		Foreground(borderColor)
 
 	metaIconStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(dimColor)
 
 	// ── Preview content ───────────────────────────────────────────────────
 	previewTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(successColor)
+		Bold(true).
+		Foreground(successColor)
 
 	previewInteractTitleStyle = lipgloss.NewStyle().
-					Bold(true).
-					Foreground(warningColor)
+		Bold(true).
+		Foreground(warningColor)
 
 	previewTitleOfflineStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(dimColor)
+		Bold(true).
+		Foreground(dimColor)
 
 	previewContentStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#D1D5DB"))
+		Foreground(t.Text)
 
 	// ── General UI ────────────────────────────────────────────────────────
 	dimStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(dimColor)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(dimColor)
 
 	interactHelpStyle = lipgloss.NewStyle().
-				Foreground(warningColor).
-				Bold(true)
+		Foreground(warningColor).
+		Bold(true)
 
 	statusBarStyle = lipgloss.NewStyle().
-			Foreground(dimColor)
+		Foreground(dimColor)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(dangerColor).
-			Bold(true)
+		Foreground(dangerColor).
+		Bold(true)
 
 	separatorStyle = lipgloss.NewStyle().
-			Foreground(borderColor)
+		Foreground(borderColor)
 
 	// ── Dialog ────────────────────────────────────────────────────────────
 	dialogStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor).
-			Padding(1, 2).
-			Width(55).
-			Background(surfaceColor)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accentColor).
+		Padding(1, 2).
+		Width(55).
+		Background(surfaceColor)
 
 	dialogTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(activeColor).
-				MarginBottom(1)
+		Bold(true).
+		Foreground(activeColor).
+		MarginBottom(1)
 
 	dialogLabelStyle = lipgloss.NewStyle().
-				Foreground(textColor).
-				MarginBottom(0)
+		Foreground(textColor).
+		MarginBottom(0)
 
 	// ── Interact badge ────────────────────────────────────────────────────
 	liveTagStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#000000")).
-			Background(warningColor).
-			Bold(true).
-			Padding(0, 1)
-)
+		Foreground(t.OnWarning).
+		Background(warningColor).
+		Bold(true).
+		Reverse(t.Reverse).
+		Padding(0, 1)
+
+	// ── Diff viewer ───────────────────────────────────────────────────────
+	diffAddStyle = lipgloss.NewStyle().
+		Foreground(successColor)
+
+	diffDelStyle = lipgloss.NewStyle().
+		Foreground(dangerColor)
+
+	diffHunkStyle = lipgloss.NewStyle().
+		Foreground(infoColor)
+
+	diffMetaStyle = lipgloss.NewStyle().
+		Foreground(brightColor).
+		Bold(true)
+
+	synKeywordStyle = lipgloss.NewStyle().
+		Foreground(accentColor).
+		Bold(true)
+
+	synStringStyle = lipgloss.NewStyle().
+		Foreground(warningColor)
+
+	synCommentStyle = lipgloss.NewStyle().
+		Foreground(dimColor).
+		Italic(true)
+}
@@ -0,0 +1,335 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+	"claude-session-manager/internal/usage"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxTemplateRows is the number of templates listed at once in the picker.
+const maxTemplateRows = 10
+
+// launchFor returns how to start claude for a session. A fresh session
+// whose transcript does not exist yet starts its conversation under its
+// SessionID, forking ForkOf when set; every other session is resumed. The
+// transcript is looked up on disk, so views use isNewConversation instead.
+func launchFor(s model.Session) tmux.Launch {
+	l := tmux.Launch{Resume: s.SessionID, Args: s.Args, Env: s.Env}
+	if !s.Fresh {
+		return l
+	}
+	if _, err := usage.TranscriptPath(s.Path, s.SessionID); err == nil {
+		return l
+	}
+	args := []string{"--session-id", s.SessionID}
+	if s.ForkOf != "" {
+		args = append([]string{"--fork-session"}, args...)
+	}
+	l.Resume = s.ForkOf
+	l.Args = append(args, s.Args...)
+	return l
+}
+
+// initSession gives a newly added session the settings of tpl, if any, and
+// marks its SessionID as one chosen by ccdeck when fresh is set.
+func (m *Model) initSession(gi, si int, tpl *model.Template, fresh bool) {
+	s := &m.store.Data.Groups[gi].Sessions[si]
+	if tpl != nil {
+		tpl.Apply(s)
+	}
+	s.Fresh = fresh
+}
+
+// openSaveTemplate asks for a name under which to save the selected
+// session's settings.
+func (m Model) openSaveTemplate() (tea.Model, tea.Cmd) {
+	if m.onGroupHeader() || len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	s := m.store.Sessions(m.groupIdx)[m.sessionIdx]
+	m.dialog = dialogSaveTemplate
+	m.inputs = []textinput.Model{newInput("Template name", "e.g. api backend", 30)}
+	m.inputs[0].SetValue(s.Name)
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) submitSaveTemplate() (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(m.inputs[0].Value())
+	if name == "" {
+		m.statusMsg = "Template name cannot be empty"
+		return m, nil
+	}
+	s := m.store.Sessions(m.groupIdx)[m.sessionIdx]
+	m.store.SaveTemplate(model.TemplateFrom(name, s))
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.dialog = dialogNone
+	m.inputs = nil
+	m.statusMsg = fmt.Sprintf("Saved template %s (press N to use it)", name)
+	return m, nil
+}
+
+// openTemplates shows the template picker for a new session in the
+// selected group.
+func (m Model) openTemplates() (tea.Model, tea.Cmd) {
+	if len(m.store.Groups()) == 0 {
+		m.statusMsg = "Create a group first (press g)"
+		return m, nil
+	}
+	if len(m.store.Data.Templates) == 0 {
+		m.statusMsg = "No templates yet. Press T on a session to save one."
+		return m, nil
+	}
+	m.templateIdx = 0
+	m.dialog = dialogTemplates
+	m.inputs = []textinput.Model{newInput("Filter", "type to filter", 40)}
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+// filteredTemplates returns the templates whose name or path contains the
+// filter text.
+func (m Model) filteredTemplates() []model.Template {
+	filter := ""
+	if len(m.inputs) > 0 {
+		filter = strings.ToLower(strings.TrimSpace(m.inputs[0].Value()))
+	}
+	var out []model.Template
+	for _, t := range m.store.Data.Templates {
+		if strings.Contains(strings.ToLower(t.Name), filter) ||
+			strings.Contains(strings.ToLower(t.Path), filter) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (m Model) updateTemplates(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := m.filteredTemplates()
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.dialog = dialogNone
+		m.inputs = nil
+		return m, nil
+	case msg.Type == tea.KeyUp:
+		m.templateIdx = max(m.templateIdx-1, 0)
+		return m, nil
+	case msg.Type == tea.KeyDown:
+		m.templateIdx = min(m.templateIdx+1, max(len(list)-1, 0))
+		return m, nil
+	case key.Matches(msg, keys.DelTmpl):
+		if m.templateIdx < len(list) {
+			m.store.DeleteTemplate(list[m.templateIdx].Name)
+			if err := m.store.Save(); err != nil {
+				m.err = err
+			}
+			m.statusMsg = fmt.Sprintf("Deleted template %s", list[m.templateIdx].Name)
+			m.templateIdx = min(m.templateIdx, max(len(list)-2, 0))
+		}
+		return m, nil
+	case msg.Type == tea.KeyEnter:
+		if m.templateIdx >= len(list) {
+			return m, nil
+		}
+		return m.useTemplate(list[m.templateIdx])
+	}
+
+	var cmd tea.Cmd
+	m.inputs[0], cmd = m.inputs[0].Update(msg)
+	m.templateIdx = min(m.templateIdx, max(len(m.filteredTemplates())-1, 0))
+	return m, cmd
+}
+
+// useTemplate opens the new session dialog with the template's path. An
+// empty session ID then starts a new conversation.
+func (m Model) useTemplate(t model.Template) (tea.Model, tea.Cmd) {
+	m.newTemplate = &t
+	m.dialog = dialogNewSession
+	m.pathCompletions = nil
+	m.inputs = []textinput.Model{
+		newInput("Project path", "~/projects/my-app", 60),
+		newInput("Session ID / Name", "empty for a new conversation", 60),
+		newInput("Display name (optional)", "e.g. api-refactor", 30),
+		newInput("Worktree branch (optional)", "new branch for a git worktree", 40),
+	}
+	m.inputs[0].SetValue(t.Path)
+	m.inputs[0].CursorEnd()
+	m.pathCheck = checkPath(t.Path)
+	m.pathCheck.gitRoot = m.rootCache[m.pathCheck.dir()]
+	m.inputIdx = 2
+	m.inputs[2].Focus()
+	return m, tea.Batch(textinput.Blink, m.lookupGitRoot())
+}
+
+func (m Model) renderTemplatesDialog() string {
+	title := dialogTitleStyle.Render("⎘ New Session from Template")
+	list := m.filteredTemplates()
+	var rows []string
+	start := max(min(m.templateIdx-maxTemplateRows/2, len(list)-maxTemplateRows), 0)
+	for i := start; i < len(list) && i < start+maxTemplateRows; i++ {
+		t := list[i]
+		line := t.Name + dimStyle.Render("  "+truncate(t.Path, 30))
+		if i == m.templateIdx {
+			rows = append(rows, selectArrowStyle.Render("› ")+metaNameStyle.Render(line))
+		} else {
+			rows = append(rows, "  "+metaValueStyle.Render(line))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, dimStyle.Render("  No matching templates"))
+	}
+	detail := ""
+	if m.templateIdx < len(list) {
+		if s := list[m.templateIdx].Summary(); s != "" {
+			detail = "\n\n" + dimStyle.Render(truncate(s, 56))
+		}
+	}
+	hint := dimStyle.Render("↑↓ select  ↵ use  ctrl+d delete  esc cancel")
+	return dialogStyle.Width(64).Render(title + "\n\n" + m.inputs[0].View() + "\n\n" + strings.Join(rows, "\n") + detail + "\n\n" + hint)
+}
+
+// openClone asks for the name of a copy of the selected session.
+func (m Model) openClone() (tea.Model, tea.Cmd) {
+	if m.onGroupHeader() || len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	s := m.store.Sessions(m.groupIdx)[m.sessionIdx]
+	m.dialog = dialogClone
+	m.cloneFork = false
+	m.inputs = []textinput.Model{newInput("Name", "name of the copy", 30)}
+	m.inputs[0].SetValue(m.uniqueSessionName(m.groupIdx, s.Name+" copy"))
+	m.inputs[0].CursorEnd()
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) updateClone(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.dialog = dialogNone
+		m.inputs = nil
+		return m, nil
+	case msg.Type == tea.KeyTab, msg.Type == tea.KeyShiftTab:
+		m.cloneFork = !m.cloneFork
+		return m, nil
+	case msg.Type == tea.KeyEnter:
+		return m.submitClone()
+	}
+	var cmd tea.Cmd
+	m.inputs[0], cmd = m.inputs[0].Update(msg)
+	return m, cmd
+}
+
+// submitClone adds a copy of the selected session to its group with a new
+// Claude session ID. The conversation starts on the first launch, empty or
+// as a fork of the original's.
+func (m Model) submitClone() (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(m.inputs[0].Value())
+	if name == "" {
+		m.statusMsg = "Name cannot be empty"
+		return m, nil
+	}
+	if m.tmuxNameTaken(m.groupIdx, name) {
+		m.statusMsg = fmt.Sprintf("A session named %s already exists here", name)
+		return m, nil
+	}
+	src := m.store.Sessions(m.groupIdx)[m.sessionIdx]
+	tpl := model.TemplateFrom(src.Name, src)
+	si := m.store.AddSession(m.groupIdx, name, model.NewUUID(), src.Path)
+	m.initSession(m.groupIdx, si, &tpl, true)
+	if m.cloneFork {
+		m.store.Data.Groups[m.groupIdx].Sessions[si].ForkOf = src.SessionID
+	}
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.sessionIdx = si
+	m.inPinned = false
+	m.dialog = dialogNone
+	m.inputs = nil
+	how := "new conversation"
+	if m.cloneFork {
+		how = "fork of " + src.Name
+	}
+	m.statusMsg = fmt.Sprintf("Cloned %s as %s (%s, starts on launch)", src.Name, name, how)
+	return m, nil
+}
+
+func (m Model) renderCloneDialog() string {
+	src := m.store.Sessions(m.groupIdx)[m.sessionIdx]
+	title := dialogTitleStyle.Render("⎘ Clone " + truncate(src.Name, 36))
+	label := dialogLabelStyle.Render("Name:")
+	newConv, fork := "( ) ", "( ) "
+	if m.cloneFork {
+		fork = "(•) "
+	} else {
+		newConv = "(•) "
+	}
+	modes := metaValueStyle.Render(newConv+"new conversation") + "\n" +
+		metaValueStyle.Render(fork+"fork this conversation (--fork-session)")
+	note := dimStyle.Render("Same path, args, env and tags; a new session ID.")
+	hint := dimStyle.Render("tab switch mode  ↵ clone  esc cancel")
+	return dialogStyle.Render(title + "\n\n" + label + "\n" + m.inputs[0].View() + "\n\n" + modes + "\n\n" + note + "\n\n" + hint)
+}
+
+// openLaunchSettings edits the claude args and env of the selected session.
+func (m Model) openLaunchSettings() (tea.Model, tea.Cmd) {
+	if m.onGroupHeader() || len(m.store.Groups()) == 0 {
+		return m, nil
+	}
+	s := m.store.Sessions(m.groupIdx)[m.sessionIdx]
+	m.dialog = dialogLaunchSettings
+	m.inputs = []textinput.Model{
+		newInput("Claude args", "e.g. --model opus --permission-mode plan", 50),
+		newInput("Env", "KEY=value, space separated", 50),
+	}
+	m.inputs[0].SetValue(model.JoinWords(s.Args))
+	m.inputs[1].SetValue(model.FormatEnv(s.Env))
+	m.inputIdx = 0
+	m.inputs[0].Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) submitLaunchSettings() (tea.Model, tea.Cmd) {
+	args, err := model.SplitWords(m.inputs[0].Value())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	env, err := model.ParseEnv(m.inputs[1].Value())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	s := &m.store.Data.Groups[m.groupIdx].Sessions[m.sessionIdx]
+	s.Args = args
+	s.Env = env
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.dialog = dialogNone
+	m.inputs = nil
+	m.statusMsg = fmt.Sprintf("Launch settings of %s apply from the next launch", s.Name)
+	return m, nil
+}
+
+func (m Model) renderLaunchSettingsDialog() string {
+	title := dialogTitleStyle.Render("⚙ Launch Settings")
+	fields := dialogLabelStyle.Render("Claude args:") + "\n" + m.inputs[0].View() + "\n\n" +
+		dialogLabelStyle.Render("Env (KEY=value ...):") + "\n" + m.inputs[1].View()
+	hint := dimStyle.Render("Applies from the next launch (R restarts)") + "\n" +
+		dimStyle.Render("tab next field  ↵ save  esc cancel")
+	return dialogStyle.Width(64).Render(title + "\n\n" + fields + "\n\n" + hint)
+}
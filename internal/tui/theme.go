@@ -0,0 +1,244 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"claude-session-manager/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a named color palette. Every style in styles.go is derived from it.
+type Theme struct {
+	Name string
+
+	Accent      lipgloss.TerminalColor
+	AccentDim   lipgloss.TerminalColor
+	Dim         lipgloss.TerminalColor
+	Muted       lipgloss.TerminalColor
+	Text        lipgloss.TerminalColor
+	Bright      lipgloss.TerminalColor
+	Active      lipgloss.TerminalColor
+	Success     lipgloss.TerminalColor
+	Warning     lipgloss.TerminalColor
+	Danger      lipgloss.TerminalColor
+	Info        lipgloss.TerminalColor
+	Surface     lipgloss.TerminalColor
+	Surface2    lipgloss.TerminalColor
+	Border      lipgloss.TerminalColor
+	BorderDim   lipgloss.TerminalColor
+	Highlight   lipgloss.TerminalColor
+	OnAccent    lipgloss.TerminalColor
+	OnHighlight lipgloss.TerminalColor
+	OnWarning   lipgloss.TerminalColor
+	Input       lipgloss.TerminalColor
+
+	// Reverse marks the selection with reverse video instead of a background
+	// color; used when colors are disabled.
+	Reverse bool
+}
+
+var darkTheme = Theme{
+	Name:        "dark",
+	Accent:      lipgloss.Color("#7C3AED"),
+	AccentDim:   lipgloss.Color("#5B21B6"),
+	Dim:         lipgloss.Color("#6B7280"),
+	Muted:       lipgloss.Color("#9CA3AF"),
+	Text:        lipgloss.Color("#D1D5DB"),
+	Bright:      lipgloss.Color("#F3F4F6"),
+	Active:      lipgloss.Color("#A78BFA"),
+	Success:     lipgloss.Color("#10B981"),
+	Warning:     lipgloss.Color("#F59E0B"),
+	Danger:      lipgloss.Color("#EF4444"),
+	Info:        lipgloss.Color("#3B82F6"),
+	Surface:     lipgloss.Color("#111827"),
+	Surface2:    lipgloss.Color("#1F2937"),
+	Border:      lipgloss.Color("#374151"),
+	BorderDim:   lipgloss.Color("#1F2937"),
+	Highlight:   lipgloss.Color("#312E81"),
+	OnAccent:    lipgloss.Color("#FFFFFF"),
+	OnHighlight: lipgloss.Color("#FFFFFF"),
+	OnWarning:   lipgloss.Color("#000000"),
+	Input:       lipgloss.Color("#E0E0FF"),
+}
+
+var lightTheme = Theme{
+	Name:        "light",
+	Accent:      lipgloss.Color("#6D28D9"),
+	AccentDim:   lipgloss.Color("#8B5CF6"),
+	Dim:         lipgloss.Color("#6B7280"),
+	Muted:       lipgloss.Color("#4B5563"),
+	Text:        lipgloss.Color("#1F2937"),
+	Bright:      lipgloss.Color("#111827"),
+	Active:      lipgloss.Color("#6D28D9"),
+	Success:     lipgloss.Color("#047857"),
+	Warning:     lipgloss.Color("#B45309"),
+	Danger:      lipgloss.Color("#B91C1C"),
+	Info:        lipgloss.Color("#1D4ED8"),
+	Surface:     lipgloss.Color("#F9FAFB"),
+	Surface2:    lipgloss.Color("#F3F4F6"),
+	Border:      lipgloss.Color("#9CA3AF"),
+	BorderDim:   lipgloss.Color("#D1D5DB"),
+	Highlight:   lipgloss.Color("#DDD6FE"),
+	OnAccent:    lipgloss.Color("#FFFFFF"),
+	OnHighlight: lipgloss.Color("#1E1B4B"),
+	OnWarning:   lipgloss.Color("#FFFFFF"),
+	Input:       lipgloss.Color("#1E1B4B"),
+}
+
+var highContrastTheme = Theme{
+	Name:        "high-contrast",
+	Accent:      lipgloss.Color("#FFFF00"),
+	AccentDim:   lipgloss.Color("#FFD700"),
+	Dim:         lipgloss.Color("#C0C0C0"),
+	Muted:       lipgloss.Color("#E0E0E0"),
+	Text:        lipgloss.Color("#FFFFFF"),
+	Bright:      lipgloss.Color("#FFFFFF"),
+	Active:      lipgloss.Color("#00FFFF"),
+	Success:     lipgloss.Color("#00FF00"),
+	Warning:     lipgloss.Color("#FFFF00"),
+	Danger:      lipgloss.Color("#FF3030"),
+	Info:        lipgloss.Color("#00BFFF"),
+	Surface:     lipgloss.Color("#000000"),
+	Surface2:    lipgloss.Color("#000000"),
+	Border:      lipgloss.Color("#FFFFFF"),
+	BorderDim:   lipgloss.Color("#C0C0C0"),
+	Highlight:   lipgloss.Color("#FFFF00"),
+	OnAccent:    lipgloss.Color("#000000"),
+	OnHighlight: lipgloss.Color("#000000"),
+	OnWarning:   lipgloss.Color("#000000"),
+	Input:       lipgloss.Color("#FFFFFF"),
+}
+
+// noColorTheme honors NO_COLOR (https://no-color.org/): no foreground or
+// background colors, selection shown in reverse video.
+var noColorTheme = Theme{
+	Name:        "none",
+	Accent:      lipgloss.NoColor{},
+	AccentDim:   lipgloss.NoColor{},
+	Dim:         lipgloss.NoColor{},
+	Muted:       lipgloss.NoColor{},
+	Text:        lipgloss.NoColor{},
+	Bright:      lipgloss.NoColor{},
+	Active:      lipgloss.NoColor{},
+	Success:     lipgloss.NoColor{},
+	Warning:     lipgloss.NoColor{},
+	Danger:      lipgloss.NoColor{},
+	Info:        lipgloss.NoColor{},
+	Surface:     lipgloss.NoColor{},
+	Surface2:    lipgloss.NoColor{},
+	Border:      lipgloss.NoColor{},
+	BorderDim:   lipgloss.NoColor{},
+	Highlight:   lipgloss.NoColor{},
+	OnAccent:    lipgloss.NoColor{},
+	OnHighlight: lipgloss.NoColor{},
+	OnWarning:   lipgloss.NoColor{},
+	Input:       lipgloss.NoColor{},
+	Reverse:     true,
+}
+
+var builtinThemes = map[string]Theme{
+	darkTheme.Name:         darkTheme,
+	lightTheme.Name:        lightTheme,
+	highContrastTheme.Name: highContrastTheme,
+}
+
+func init() {
+	applyTheme(darkTheme)
+}
+
+// ApplyTheme resolves the theme selected in cfg and rebuilds all styles.
+// It must be called before the program starts, since "auto" queries the
+// terminal background color.
+func ApplyTheme(cfg *model.Config) error {
+	t, err := resolveTheme(cfg)
+	if err != nil {
+		return err
+	}
+	applyTheme(t)
+	return nil
+}
+
+func resolveTheme(cfg *model.Config) (Theme, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		return noColorTheme, nil
+	}
+	name := ""
+	if cfg != nil {
+		name = strings.TrimSpace(cfg.Theme)
+	}
+	if name == "" || name == "auto" {
+		if lipgloss.HasDarkBackground() {
+			return darkTheme, nil
+		}
+		return lightTheme, nil
+	}
+	if t, ok := builtinThemes[name]; ok {
+		return t, nil
+	}
+	if cfg != nil {
+		if tc, ok := cfg.Themes[name]; ok {
+			return customTheme(name, tc)
+		}
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(themeNames(cfg), ", "))
+}
+
+func customTheme(name string, tc model.ThemeConfig) (Theme, error) {
+	base := darkTheme
+	if tc.Base != "" {
+		b, ok := builtinThemes[tc.Base]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %q: unknown base theme %q", name, tc.Base)
+		}
+		base = b
+	}
+	base.Name = name
+	slots := map[string]*lipgloss.TerminalColor{
+		"accent":       &base.Accent,
+		"accent_dim":   &base.AccentDim,
+		"dim":          &base.Dim,
+		"muted":        &base.Muted,
+		"text":         &base.Text,
+		"bright":       &base.Bright,
+		"active":       &base.Active,
+		"success":      &base.Success,
+		"warning":      &base.Warning,
+		"danger":       &base.Danger,
+		"info":         &base.Info,
+		"surface":      &base.Surface,
+		"surface2":     &base.Surface2,
+		"border":       &base.Border,
+		"border_dim":   &base.BorderDim,
+		"highlight":    &base.Highlight,
+		"on_accent":    &base.OnAccent,
+		"on_highlight": &base.OnHighlight,
+		"on_warning":   &base.OnWarning,
+		"input":        &base.Input,
+	}
+	for k, v := range tc.Colors {
+		slot, ok := slots[k]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %q: unknown color key %q", name, k)
+		}
+		*slot = lipgloss.Color(v)
+	}
+	return base, nil
+}
+
+func themeNames(cfg *model.Config) []string {
+	names := []string{"auto"}
+	for n := range builtinThemes {
+		names = append(names, n)
+	}
+	if cfg != nil {
+		for n := range cfg.Themes {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
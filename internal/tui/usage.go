@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"os"
+	"time"
+
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/usage"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// usageTTL is how often the transcripts of visible sessions are checked for
+// new usage. Unchanged transcripts are not parsed again.
+const usageTTL = 30 * time.Second
+
+// usageEntry is the cached usage of one session transcript.
+type usageEntry struct {
+	usage   usage.Usage
+	modTime time.Time // of the transcript when it was parsed
+	fetched time.Time
+}
+
+type usageMsg struct {
+	entries map[string]usageEntry // by Claude session ID
+}
+
+// staleUsage returns the visible sessions whose usage is missing or older
+// than usageTTL and not already being read.
+func (m Model) staleUsage() []model.Session {
+	now := time.Now()
+	seen := make(map[string]bool)
+	var stale []model.Session
+	for gi, g := range m.store.Groups() {
+		if !m.expanded[gi] && gi != m.groupIdx {
+			continue
+		}
+		for _, s := range g.Sessions {
+			id := s.SessionID
+			if id == "" || seen[id] || m.usagePending[id] {
+				continue
+			}
+			seen[id] = true
+			if e, ok := m.usageCache[id]; ok && now.Sub(e.fetched) < usageTTL {
+				continue
+			}
+			stale = append(stale, s)
+		}
+	}
+	return stale
+}
+
+// refreshUsage starts a background read of every stale transcript.
+func (m *Model) refreshUsage() tea.Cmd {
+	stale := m.staleUsage()
+	if len(stale) == 0 {
+		return nil
+	}
+	prev := make(map[string]usageEntry, len(stale))
+	for _, s := range stale {
+		m.usagePending[s.SessionID] = true
+		prev[s.SessionID] = m.usageCache[s.SessionID]
+	}
+	cfg := m.cfg
+	return func() tea.Msg {
+		entries := make(map[string]usageEntry, len(stale))
+		for _, s := range stale {
+			e := prev[s.SessionID]
+			e.fetched = time.Now()
+			path, err := usage.TranscriptPath(s.Path, s.SessionID)
+			if err != nil {
+				entries[s.SessionID] = usageEntry{fetched: e.fetched}
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil || info.ModTime().Equal(e.modTime) {
+				entries[s.SessionID] = e
+				continue
+			}
+			if u, err := usage.ReadFile(path, time.Time{}, cfg); err == nil {
+				e.usage, e.modTime = u, info.ModTime()
+			}
+			entries[s.SessionID] = e
+		}
+		return usageMsg{entries: entries}
+	}
+}
+
+func (m Model) handleUsage(msg usageMsg) (tea.Model, tea.Cmd) {
+	for id, e := range msg.entries {
+		m.usageCache[id] = e
+		delete(m.usagePending, id)
+	}
+	return m, nil
+}
+
+// usageFor returns the cached usage of a session.
+func (m Model) usageFor(s model.Session) (usage.Usage, bool) {
+	e, ok := m.usageCache[s.SessionID]
+	return e.usage, ok && s.SessionID != ""
+}
+
+// groupUsage sums the cached usage of every session in group gi.
+func (m Model) groupUsage(gi int) usage.Usage {
+	var total usage.Usage
+	seen := make(map[string]bool)
+	for _, g := range m.store.Subtree(gi) {
+		for _, s := range m.store.Sessions(g) {
+			if seen[s.SessionID] {
+				continue
+			}
+			seen[s.SessionID] = true
+			if u, ok := m.usageFor(s); ok {
+				total.Add(u)
+			}
+		}
+	}
+	return total
+}
+
+// renderUsageLine formats cost and token counts for the metadata header.
+func renderUsageLine(u usage.Usage) string {
+	if u.Tokens() == 0 {
+		return dimStyle.Render("no usage recorded")
+	}
+	return metaValueStyle.Render(u.FormatCost()) + dimStyle.Render(
+		"  "+usage.FormatTokens(u.Input)+" in · "+usage.FormatTokens(u.Output)+" out · "+
+			usage.FormatTokens(u.CacheWrite+u.CacheRead)+" cache")
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"claude-session-manager/internal/git"
+	"claude-session-manager/internal/model"
+	"claude-session-manager/internal/tmux"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// safeBranchRe matches characters replaced when a branch name becomes a
+// directory name.
+var safeBranchRe = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// worktreeCreatedMsg carries the session to add once its worktree exists.
+type worktreeCreatedMsg struct {
+	groupID   string
+	name      string
+	sessionID string
+	repo      string
+	dir       string
+	template  *model.Template
+	err       error
+}
+
+type worktreeRemovedMsg struct {
+	id   string // Session.ID, deleted once the worktree is gone
+	name string
+	dir  string
+	err  error
+}
+
+// worktreeDir returns where the worktree for branch is created: a
+// "<repo>.worktrees" directory next to the repository.
+func worktreeDir(repo, branch string) string {
+	name := strings.Trim(safeBranchRe.ReplaceAllString(branch, "-"), "-")
+	return filepath.Join(filepath.Dir(repo), filepath.Base(repo)+".worktrees", name)
+}
+
+// createWorktreeCmd runs git worktree add in the background for the
+// repository containing path, resolving the repository first if msg.repo is
+// not known yet.
+func createWorktreeCmd(msg worktreeCreatedMsg, path, branch string) tea.Cmd {
+	return func() tea.Msg {
+		if msg.repo == "" {
+			root, err := git.RepoRoot(path)
+			if err != nil {
+				msg.err = fmt.Errorf("a worktree needs a path inside a git repository: %w", err)
+				return msg
+			}
+			msg.repo = root
+		}
+		msg.dir = worktreeDir(msg.repo, branch)
+		msg.err = git.AddWorktree(msg.repo, msg.dir, branch)
+		return msg
+	}
+}
+
+func (m Model) handleWorktreeCreated(msg worktreeCreatedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	}
+	gi := -1
+	for i, g := range m.store.Groups() {
+		if g.ID == msg.groupID {
+			gi = i
+		}
+	}
+	if gi < 0 {
+		m.statusMsg = fmt.Sprintf("Worktree created at %s, but its group is gone", msg.dir)
+		return m, nil
+	}
+	si := m.store.AddSession(gi, msg.name, msg.sessionID, msg.dir)
+	m.store.Data.Groups[gi].Sessions[si].WorktreeOf = msg.repo
+	m.initSession(gi, si, msg.template, false)
+	if err := m.store.Save(); err != nil {
+		m.err = err
+	}
+	m.groupIdx, m.sessionIdx = gi, si
+	m.expanded[gi] = true
+	m.statusMsg = fmt.Sprintf("Created session %s in worktree %s", msg.name, msg.dir)
+	return m, nil
+}
+
+// checkWorktreeRemovable refuses removal when the worktree has uncommitted
+// changes, so deleting a session never loses work.
+func checkWorktreeRemovable(dir string) error {
+	st, err := git.GetStatus(dir)
+	if err != nil {
+		return err
+	}
+	if st.Dirty > 0 {
+		return fmt.Errorf("worktree %s has %d uncommitted changes; commit or stash them first", dir, st.Dirty)
+	}
+	return nil
+}
+
+// removeWorktreeCmd stops the session if it is running, then removes its
+// worktree. id is the Session.ID to delete once that succeeds.
+func (m Model) removeWorktreeCmd(id string, j lifecycleJob, repo string, running bool) tea.Cmd {
+	timeout := m.cfg.StopTimeout()
+	return func() tea.Msg {
+		msg := worktreeRemovedMsg{id: id, name: j.name, dir: j.path}
+		if running {
+			if _, err := tmux.StopSession(j.tmuxName, timeout); err != nil {
+				msg.err = err
+				return msg
+			}
+		}
+		msg.err = git.RemoveWorktree(repo, j.path)
+		return msg
+	}
+}
+
+// deleteWithWorktree removes the worktree the selected session was created
+// in, refusing while it has uncommitted changes, and deletes the session
+// once the worktree is gone. On failure the session is kept, so the
+// worktree is never left without a record.
+func (m Model) deleteWithWorktree() (tea.Model, tea.Cmd) {
+	sessions := m.store.Sessions(m.groupIdx)
+	if m.sessionIdx < 0 || m.sessionIdx >= len(sessions) || sessions[m.sessionIdx].WorktreeOf == "" {
+		return m, nil
+	}
+	repo := sessions[m.sessionIdx].WorktreeOf
+	j := m.jobAt(m.groupIdx, m.sessionIdx)
+	if err := checkWorktreeRemovable(j.path); err != nil {
+		m.statusMsg = fmt.Sprintf("Not deleted: %v", err)
+		m.dialog = dialogNone
+		return m, nil
+	}
+	running := m.tmuxSessions[j.tmuxName]
+	m.dialog = dialogNone
+	m.statusMsg = fmt.Sprintf("Removing worktree of %s...", j.name)
+	return m, m.removeWorktreeCmd(sessions[m.sessionIdx].ID, j, repo, running)
+}
+
+func (m Model) handleWorktreeRemoved(msg worktreeRemovedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Worktree of %s not removed: %v", msg.name, msg.err)
+		return m, nil
+	}
+	for gi, g := range m.store.Groups() {
+		for si, s := range g.Sessions {
+			if s.ID == msg.id {
+				m.deleteSessionAt(gi, si)
+				if err := m.store.Save(); err != nil {
+					m.err = err
+				}
+				m.statusMsg = fmt.Sprintf("Deleted session %s and removed worktree %s", msg.name, msg.dir)
+				return m, nil
+			}
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Removed worktree %s", msg.dir)
+	return m, nil
+}
@@ -0,0 +1,266 @@
+// Package usage reads token usage from Claude's JSONL transcripts and
+// prices it with the configured price table.
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"claude-session-manager/internal/model"
+)
+
+// projectDirRe matches the characters Claude replaces with "-" when it
+// names a project's transcript directory after its path.
+var projectDirRe = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// Usage is the token usage of one or more transcripts.
+type Usage struct {
+	Input      int64
+	Output     int64
+	CacheWrite int64
+	CacheRead  int64
+	Cost       float64 // US dollars
+	// Unpriced is set when some tokens came from a model without a price,
+	// so Cost is a lower bound.
+	Unpriced bool
+}
+
+// Add accumulates o into u.
+func (u *Usage) Add(o Usage) {
+	u.Input += o.Input
+	u.Output += o.Output
+	u.CacheWrite += o.CacheWrite
+	u.CacheRead += o.CacheRead
+	u.Cost += o.Cost
+	u.Unpriced = u.Unpriced || o.Unpriced
+}
+
+// Tokens returns the total number of tokens of every kind.
+func (u Usage) Tokens() int64 {
+	return u.Input + u.Output + u.CacheWrite + u.CacheRead
+}
+
+// ProjectsDir returns where Claude keeps transcripts: $CLAUDE_CONFIG_DIR or
+// ~/.claude, followed by "projects".
+func ProjectsDir() (string, error) {
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "projects"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find home directory: %w", err)
+	}
+	return filepath.Join(home, ".claude", "projects"), nil
+}
+
+// ProjectDir returns the directory holding the transcripts of Claude
+// sessions started in projectPath.
+func ProjectDir(projectPath string) (string, error) {
+	dir, err := ProjectsDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(model.ExpandPath(projectPath))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, projectDirRe.ReplaceAllString(abs, "-")), nil
+}
+
+// FirstSessionSince returns the ID of the first Claude session started in
+// projectPath at or after since, judged by the timestamp of the first entry
+// of its transcript, so that older conversations resumed since are not
+// mistaken for new ones. Sessions in skip are ignored. It returns "" when
+// there is none yet.
+func FirstSessionSince(projectPath string, since time.Time, skip map[string]bool) (string, error) {
+	dir, err := ProjectDir(projectPath)
+	if err != nil {
+		return "", err
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return "", err
+	}
+	first, firstAt := "", time.Time{}
+	for _, p := range matches {
+		id := strings.TrimSuffix(filepath.Base(p), ".jsonl")
+		if skip[id] {
+			continue
+		}
+		// A transcript last written before since cannot have started after.
+		info, err := os.Stat(p)
+		if err != nil || info.ModTime().Before(since) {
+			continue
+		}
+		started, err := startedAt(p)
+		if err != nil || started.IsZero() || started.Before(since) {
+			continue
+		}
+		if first == "" || started.Before(firstAt) {
+			first, firstAt = id, started
+		}
+	}
+	return first, nil
+}
+
+// startedAt returns the timestamp of the first transcript entry that has
+// one, or the zero time when none of the first lines does.
+func startedAt(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for i := 0; i < 50 && sc.Scan(); i++ {
+		var e struct {
+			Timestamp time.Time `json:"timestamp"`
+		}
+		if json.Unmarshal(sc.Bytes(), &e) == nil && !e.Timestamp.IsZero() {
+			return e.Timestamp, nil
+		}
+	}
+	return time.Time{}, sc.Err()
+}
+
+// TranscriptPath returns the transcript of a Claude session started in
+// projectPath. If the project directory was moved since, the transcript is
+// looked up by session ID across all projects.
+func TranscriptPath(projectPath, sessionID string) (string, error) {
+	if sessionID == "" {
+		return "", os.ErrNotExist
+	}
+	dir, err := ProjectsDir()
+	if err != nil {
+		return "", err
+	}
+	name := sessionID + ".jsonl"
+	if pdir, err := ProjectDir(projectPath); err == nil {
+		p := filepath.Join(pdir, name)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*", name))
+	if err != nil {
+		return "", fmt.Errorf("cannot search transcripts: %w", err)
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no transcript for session %s: %w", sessionID, os.ErrNotExist)
+	}
+	return matches[0], nil
+}
+
+// entry is the part of a transcript line that carries usage.
+type entry struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			Input      int64 `json:"input_tokens"`
+			Output     int64 `json:"output_tokens"`
+			CacheWrite int64 `json:"cache_creation_input_tokens"`
+			CacheRead  int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ReadFile sums the usage recorded in the transcript at path since the
+// given time (zero for all of it). Claude writes one line per content block
+// of a response, each repeating the response's usage, so only the last line
+// of every message ID is counted.
+func ReadFile(path string, since time.Time, cfg *model.Config) (Usage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Usage{}, err
+	}
+	defer f.Close()
+
+	var order []string
+	last := make(map[string]entry)
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && bytes.Contains(line, []byte(`"usage"`)) {
+			var e entry
+			if json.Unmarshal(line, &e) == nil && e.Type == "assistant" && e.Message.Usage != nil &&
+				e.Message.Model != "<synthetic>" && !e.Timestamp.Before(since) {
+				id := e.Message.ID
+				if id == "" {
+					id = fmt.Sprintf("line-%d", len(order))
+				}
+				if _, seen := last[id]; !seen {
+					order = append(order, id)
+				}
+				last[id] = e
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Usage{}, fmt.Errorf("cannot read transcript: %w", err)
+		}
+	}
+
+	var u Usage
+	for _, id := range order {
+		e := last[id]
+		t := e.Message.Usage
+		msg := Usage{Input: t.Input, Output: t.Output, CacheWrite: t.CacheWrite, CacheRead: t.CacheRead}
+		if p, ok := cfg.PriceFor(e.Message.Model); ok {
+			msg.Cost = (float64(t.Input)*p.Input + float64(t.Output)*p.Output +
+				float64(t.CacheWrite)*p.CacheWrite + float64(t.CacheRead)*p.CacheRead) / 1e6
+		} else if msg.Tokens() > 0 {
+			msg.Unpriced = true
+		}
+		u.Add(msg)
+	}
+	return u, nil
+}
+
+// ForSession returns the usage of a stored session since the given time. A
+// session whose transcript does not exist yet has no usage.
+func ForSession(s model.Session, since time.Time, cfg *model.Config) (Usage, error) {
+	path, err := TranscriptPath(s.Path, s.SessionID)
+	if errors.Is(err, os.ErrNotExist) {
+		return Usage{}, nil
+	}
+	if err != nil {
+		return Usage{}, err
+	}
+	return ReadFile(path, since, cfg)
+}
+
+// FormatTokens abbreviates a token count: 950, 12.3k, 4.1M.
+func FormatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// FormatCost formats a cost in dollars, marking lower bounds with "+".
+func (u Usage) FormatCost() string {
+	s := fmt.Sprintf("$%.2f", u.Cost)
+	if u.Unpriced {
+		s += "+"
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package usage
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"claude-session-manager/internal/model"
+)
+
+// The fixture holds two lines for msg_1 and msg_2 each (only the last one of
+// a message counts), a <synthetic> message, a model without a price, a
+// malformed line and a last line without a trailing newline.
+const transcriptFixture = "testdata/transcript.jsonl"
+
+func TestReadFile(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		name  string
+		since time.Time
+		cfg   *model.Config
+		want  Usage
+	}{
+		{
+			name: "whole transcript",
+			// msg_1 sonnet: 100*3 + 50*15 + 1000*3.75 + 2000*0.30 = 5400
+			// msg_2 opus: 200*5 + 300*25 + 1000*0.50 = 9000
+			want: Usage{Input: 310, Output: 360, CacheWrite: 1000, CacheRead: 3000, Cost: 0.0144, Unpriced: true},
+		},
+		{
+			name:  "since leaves out earlier messages",
+			since: at("2026-03-01T11:00:00Z"),
+			want:  Usage{Input: 210, Output: 310, CacheRead: 1000, Cost: 0.009, Unpriced: true},
+		},
+		{
+			name:  "only unpriced tokens",
+			since: at("2026-03-01T12:15:00Z"),
+			want:  Usage{Input: 10, Output: 10, Unpriced: true},
+		},
+		{
+			name:  "configured price for an unknown model",
+			since: at("2026-03-01T12:15:00Z"),
+			cfg:   &model.Config{Prices: map[string]model.Price{"mystery": {Input: 1, Output: 2}}},
+			want:  Usage{Input: 10, Output: 10, Cost: 0.00003},
+		},
+		{
+			name:  "nothing since",
+			since: at("2026-03-02T00:00:00Z"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFile(transcriptFixture, tt.since, tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got.Cost-tt.want.Cost) > 1e-9 {
+				t.Errorf("cost = %v, want %v", got.Cost, tt.want.Cost)
+			}
+			got.Cost, tt.want.Cost = 0, 0
+			if got != tt.want {
+				t.Errorf("usage = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadFileMissing(t *testing.T) {
+	if _, err := ReadFile(filepath.Join(t.TempDir(), "none.jsonl"), time.Time{}, nil); err == nil {
+		t.Error("expected an error for a missing transcript")
+	}
+}